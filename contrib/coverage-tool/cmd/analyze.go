@@ -0,0 +1,435 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/backend"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/churn"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/sarif"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/selector"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/term"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/tokenest"
+)
+
+var (
+	analyzeCoverageFile     string
+	analyzeStrategy         string
+	analyzeLimit            int
+	analyzeHistoryFile      string
+	analyzeOwner            string
+	analyzeChurnWindow      time.Duration
+	analyzeInteractive      bool
+	analyzeBackend          string
+	analyzeContextWindow    int
+	analyzeReserveTokens    int
+	analyzeBaselineFile     string
+	analyzeExcludeGenerated bool
+	analyzePerTestDir       string
+	analyzeFlagRedundant    bool
+	analyzeSarifOutput      string
+	analyzeExport           string
+	analyzeExportOutput     string
+	analyzeGroupByCrate     bool
+	analyzeLeaderboard      bool
+	analyzeNoColor          bool
+	analyzeSHA              string
+	analyzeGithubOwner      string
+	analyzeGithubRepo       string
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Report per-file coverage from a cargo llvm-cov export",
+	RunE:  runAnalyze,
+}
+
+func init() {
+	analyzeCmd.Flags().StringVar(&analyzeCoverageFile, "coverage-file", "coverage.json", "path to a `cargo llvm-cov --json` export")
+	analyzeCmd.Flags().StringVar(&analyzeStrategy, "strategy", "coverage", "selection strategy to rank files by (coverage, round-robin, churn, zero-first, regression)")
+	analyzeCmd.Flags().IntVar(&analyzeLimit, "limit", 0, "only show the top N candidates (0 for all)")
+	analyzeCmd.Flags().StringVar(&analyzeHistoryFile, "history-file", ".coverage-tool/history.json", "path to the generation history store, used by history-aware strategies")
+	analyzeCmd.Flags().StringVar(&analyzeOwner, "owner", "", "only consider files owned by this CODEOWNERS team, e.g. @dragonflyoss/storage-team")
+	analyzeCmd.Flags().DurationVar(&analyzeChurnWindow, "churn-window", 90*24*time.Hour, "how far back to count commits for the churn strategy")
+	analyzeCmd.Flags().BoolVar(&analyzeInteractive, "interactive", false, "interactively filter and select candidates instead of printing every one")
+	analyzeCmd.Flags().StringVar(&analyzeBackend, "backend", "unit", "generation backend to estimate prompt size for (unit, snapshot); defaults to the --config policy's provider, if any, when unset")
+	analyzeCmd.Flags().IntVar(&analyzeContextWindow, "context-window", 0, "model context window in tokens; candidates whose estimated prompt cannot fit are dropped (0 disables the check)")
+	analyzeCmd.Flags().IntVar(&analyzeReserveTokens, "reserve-tokens", 1024, "tokens to reserve for the completion when checking --context-window")
+	analyzeCmd.Flags().StringVar(&analyzeBaselineFile, "baseline", "", "path to a prior cargo llvm-cov --json export to detect regressions against, used by the regression strategy")
+	analyzeCmd.Flags().BoolVar(&analyzeExcludeGenerated, "exclude-generated", true, "exclude files that look machine-generated (bindgen FFI shims, include!'d generated code), since a model cannot usefully test them")
+	analyzeCmd.Flags().StringVar(&analyzePerTestDir, "per-test", "", "path to a directory of per-test `cargo llvm-cov --json` exports (one file per test); when set, print which tests cover which files instead of ranking candidates")
+	analyzeCmd.Flags().BoolVar(&analyzeFlagRedundant, "flag-redundant", false, "with --per-test, also list tests whose covered files are a strict subset of another test's")
+	analyzeCmd.Flags().StringVar(&analyzeSarifOutput, "sarif-output", "", "path to write a SARIF log flagging uncovered public functions in the candidates, for GitHub code scanning")
+	analyzeCmd.Flags().StringVar(&analyzeExport, "export", "", "export the candidates to a file instead of (or in addition to) printing them (csv)")
+	analyzeCmd.Flags().StringVar(&analyzeExportOutput, "export-output", "coverage.csv", "path to write --export's output to")
+	analyzeCmd.Flags().BoolVar(&analyzeGroupByCrate, "group-by-crate", false, "also print a per-crate coverage rollup, since maintainers often think in crates rather than individual files")
+	analyzeCmd.Flags().BoolVar(&analyzeLeaderboard, "leaderboard", false, "print a ranked leaderboard of crates by coverage shortfall instead of the per-file candidate list; exported via --export/--export-output when set")
+	analyzeCmd.Flags().BoolVar(&analyzeNoColor, "no-color", false, "disable ANSI colors in the printed table(s), e.g. for logs that don't render them (also honors NO_COLOR)")
+	analyzeCmd.Flags().StringVar(&analyzeSHA, "sha", "", "commit SHA to link candidates to on GitHub; defaults to the repo's current HEAD, resolved via git (empty on failure omits the link column)")
+	analyzeCmd.Flags().StringVar(&analyzeGithubOwner, "github-owner", "bergwolf", "GitHub organization or user owning the repository")
+	analyzeCmd.Flags().StringVar(&analyzeGithubRepo, "github-repo", "nydus", "GitHub repository name")
+}
+
+func runAnalyze(cmd *cobra.Command, _ []string) error {
+	if cfg != nil {
+		applyConfigDefault(cmd, "backend", &analyzeBackend, cfg.Provider)
+		applyConfigDefault(cmd, "strategy", &analyzeStrategy, cfg.Strategy)
+	}
+
+	if analyzePerTestDir != "" {
+		return runPerTestAttribution(analyzePerTestDir)
+	}
+
+	f, err := os.Open(analyzeCoverageFile)
+	if err != nil {
+		return errors.Wrap(err, "open coverage file")
+	}
+	defer f.Close()
+
+	report, err := coverage.Parse(f)
+	if err != nil {
+		return err
+	}
+
+	files := report.Files
+	if analyzeOwner != "" {
+		files, err = filterByOwner(files, analyzeOwner)
+		if err != nil {
+			return err
+		}
+	}
+	if analyzeExcludeGenerated {
+		files = selector.ExcludeGenerated(repoRoot, files)
+	}
+
+	colorEnabled := term.ColorEnabled(analyzeNoColor)
+
+	sha, err := resolveSHA(repoRoot, analyzeSHA)
+	if err != nil {
+		runWarnings.Warnf("could not resolve a commit SHA for GitHub permalinks: %v", err)
+	}
+
+	if analyzeLeaderboard {
+		return runLeaderboard(files, colorEnabled)
+	}
+
+	hist, err := history.Open(analyzeHistoryFile)
+	if err != nil {
+		return err
+	}
+
+	opts := selector.Options{History: hist}
+	if analyzeStrategy == "churn" {
+		if opts.CommitCounts, err = churn.CountsSince(repoRoot, analyzeChurnWindow); err != nil {
+			return err
+		}
+	}
+	if analyzeStrategy == "regression" {
+		baselineFiles, err := loadReport(analyzeBaselineFile)
+		if err != nil {
+			return err
+		}
+		opts.Baseline = make(map[string]coverage.FileStats, len(baselineFiles))
+		for _, f := range baselineFiles {
+			opts.Baseline[f.Path] = f
+		}
+	}
+
+	candidates, err := rankWithinBudget(files, analyzeStrategy, opts, analyzeLimit, analyzeContextWindow, analyzeReserveTokens, analyzeBackend)
+	if err != nil {
+		return err
+	}
+
+	if analyzeSarifOutput != "" {
+		if err := writeSarif(analyzeSarifOutput, report, candidates); err != nil {
+			return err
+		}
+	}
+
+	if analyzeInteractive {
+		chosen, err := selector.PickInteractive(candidates, os.Stdin, os.Stdout)
+		if err != nil {
+			return err
+		}
+		candidates = chosen
+	}
+
+	if analyzeExport != "" {
+		if err := exportCandidates(analyzeExport, analyzeExportOutput, candidates); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s\n", analyzeExportOutput)
+	}
+
+	headers := []string{"File", "Coverage", "Lines"}
+	if sha != "" {
+		headers = append(headers, "Source")
+	}
+	table := term.NewTable(headers...)
+	for _, file := range candidates {
+		row := []string{file.Path, term.Coverage(colorEnabled, file.Percent(), fmt.Sprintf("%.2f%%", file.Percent())), fmt.Sprintf("%d/%d", file.LinesCovered, file.LinesTotal)}
+		if sha != "" {
+			row = append(row, githubBlobURL(analyzeGithubOwner, analyzeGithubRepo, sha, file.Path))
+		}
+		table.AddRow(row...)
+	}
+	table.Render(os.Stdout)
+
+	if analyzeGroupByCrate {
+		fmt.Println()
+		printCrateRollup(files, colorEnabled)
+	}
+
+	emitWebhookEvent("analysis-done", "", map[string]any{"candidates": len(candidates)})
+
+	return nil
+}
+
+// runLeaderboard prints files' crates ranked by coverage shortfall
+// (least-covered first), the table maintainers paste into the monthly
+// community meeting notes, and exports it via --export/--export-output
+// when set.
+func runLeaderboard(files []coverage.FileStats, colorEnabled bool) error {
+	crates := coverage.RollupByCrate(files, func(path string) string { return cratePackageName(repoRoot, path) })
+
+	if analyzeExport != "" {
+		if err := exportLeaderboard(analyzeExport, analyzeExportOutput, crates); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s\n", analyzeExportOutput)
+	}
+
+	printLeaderboard(crates, colorEnabled)
+
+	emitWebhookEvent("analysis-done", "", map[string]any{"crates": len(crates)})
+
+	return nil
+}
+
+// printLeaderboard prints one table row per crate, ranked worst-covered
+// first, with its coverage shortfall (the percentage points needed to
+// reach 100%).
+func printLeaderboard(crates []coverage.CrateStats, colorEnabled bool) {
+	table := term.NewTable("Rank", "Crate", "Coverage", "Shortfall", "Lines")
+	for i, c := range crates {
+		table.AddRow(
+			fmt.Sprintf("%d.", i+1),
+			c.Crate,
+			term.Coverage(colorEnabled, c.Percent(), fmt.Sprintf("%.2f%%", c.Percent())),
+			fmt.Sprintf("%.2f%%", 100-c.Percent()),
+			fmt.Sprintf("%d/%d", c.LinesCovered, c.LinesTotal),
+		)
+	}
+	table.Render(os.Stdout)
+}
+
+// exportLeaderboard writes crates to path in format, for pasting into a
+// spreadsheet or meeting notes doc.
+func exportLeaderboard(format, path string, crates []coverage.CrateStats) error {
+	switch format {
+	case "csv":
+		return exportLeaderboardCSV(path, crates)
+	default:
+		return errors.Errorf("unknown export format %q (want csv)", format)
+	}
+}
+
+// exportLeaderboardCSV writes crates as a rank-ordered CSV: crate, lines
+// covered/total, coverage percentage, and shortfall.
+func exportLeaderboardCSV(path string, crates []coverage.CrateStats) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create export file")
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"rank", "crate", "lines_covered", "lines_total", "coverage_percent", "shortfall_percent"}); err != nil {
+		return errors.Wrap(err, "write export header")
+	}
+
+	for i, c := range crates {
+		row := []string{
+			strconv.Itoa(i + 1),
+			c.Crate,
+			strconv.Itoa(c.LinesCovered),
+			strconv.Itoa(c.LinesTotal),
+			strconv.FormatFloat(c.Percent(), 'f', 2, 64),
+			strconv.FormatFloat(100-c.Percent(), 'f', 2, 64),
+		}
+		if err := w.Write(row); err != nil {
+			return errors.Wrap(err, "write export row")
+		}
+	}
+
+	w.Flush()
+	return errors.Wrap(w.Error(), "flush export file")
+}
+
+// exportCandidates writes candidates to path in format, for import into
+// spreadsheets or other tooling outside coverage-tool.
+func exportCandidates(format, path string, candidates []coverage.FileStats) error {
+	switch format {
+	case "csv":
+		return exportCSV(path, candidates)
+	default:
+		return errors.Errorf("unknown export format %q (want csv)", format)
+	}
+}
+
+// exportCSV writes candidates as a per-file CSV: filename, lines
+// covered/total, functions covered/total, regions covered/total, and
+// overall coverage percentage.
+func exportCSV(path string, candidates []coverage.FileStats) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create export file")
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"file", "lines_covered", "lines_total", "functions_covered", "functions_total", "regions_covered", "regions_total", "coverage_percent"}); err != nil {
+		return errors.Wrap(err, "write export header")
+	}
+
+	for _, file := range candidates {
+		row := []string{
+			file.Path,
+			strconv.Itoa(file.LinesCovered),
+			strconv.Itoa(file.LinesTotal),
+			strconv.Itoa(file.FunctionsCovered),
+			strconv.Itoa(file.FunctionsTotal),
+			strconv.Itoa(file.RegionsCovered),
+			strconv.Itoa(file.RegionsTotal),
+			strconv.FormatFloat(file.Percent(), 'f', 2, 64),
+		}
+		if err := w.Write(row); err != nil {
+			return errors.Wrap(err, "write export row")
+		}
+	}
+
+	w.Flush()
+	return errors.Wrap(w.Error(), "flush export file")
+}
+
+// writeSarif flags every uncovered public function across candidates as a
+// SARIF result and writes the log to path, so GitHub code scanning can
+// surface coverage gaps inline on PR diffs.
+func writeSarif(path string, report *coverage.Report, candidates []coverage.FileStats) error {
+	var findings []sarif.Finding
+	for _, file := range candidates {
+		uncovered, err := coverage.UncoveredPublicFunctions(report, repoRoot, file.Path)
+		if err != nil {
+			runWarnings.Warnf("skipping SARIF check for %s: %v", file.Path, err)
+			continue
+		}
+		for _, fn := range uncovered {
+			findings = append(findings, sarif.Finding{
+				RuleID:  "uncovered-public-function",
+				Message: fmt.Sprintf("public function %q has no test coverage", fn.Name),
+				File:    fn.File,
+				Line:    fn.Line,
+			})
+		}
+	}
+	return sarif.Write(path, "coverage-tool", "", findings)
+}
+
+// runPerTestAttribution prints, for every file covered by at least one
+// per-test profile in dir, which tests cover it, flagging files whose
+// coverage comes from a single test so maintainers can spot redundant
+// tests and coverage with no backup.
+func runPerTestAttribution(dir string) error {
+	profiles, err := coverage.LoadPerTestProfiles(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range coverage.Attribute(profiles) {
+		marker := ""
+		if a.SingleTested {
+			marker = " (single test, no backup coverage)"
+		}
+		fmt.Printf("%-60s %s%s\n", a.Path, strings.Join(a.CoveredBy, ", "), marker)
+	}
+
+	if analyzeFlagRedundant {
+		for _, r := range coverage.FindRedundant(profiles) {
+			fmt.Printf("redundant: %s is a strict subset of %s\n", r.TestName, r.SubsumedBy)
+		}
+	}
+
+	return nil
+}
+
+// rankWithinBudget ranks files by strategy, drops any that cannot fit
+// contextWindow tokens (if set), and only then truncates to limit. Token
+// filtering must run before truncation: filtering after would drop
+// oversized files from an already-limit-sized slice instead of
+// backfilling from the next-ranked candidates, silently returning fewer
+// than limit results even when enough fitting candidates exist further
+// down the ranking.
+func rankWithinBudget(files []coverage.FileStats, strategy string, opts selector.Options, limit, contextWindow, reserveTokens int, backendName string) ([]coverage.FileStats, error) {
+	ranked, err := selector.Apply(strategy, files, 0, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if contextWindow > 0 {
+		ranked, err = filterByTokenBudget(ranked, backendName, contextWindow, reserveTokens)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if limit > 0 && limit < len(ranked) {
+		ranked = ranked[:limit]
+	}
+
+	return ranked, nil
+}
+
+// filterByTokenBudget drops candidates whose estimated generate prompt
+// (file content plus module context) cannot fit contextWindow tokens
+// after reserving reserveTokens for the model's completion, so oversized
+// files are skipped here instead of failing inside generate.
+func filterByTokenBudget(candidates []coverage.FileStats, backendName string, contextWindow, reserveTokens int) ([]coverage.FileStats, error) {
+	b, err := backend.Get(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	var fitted []coverage.FileStats
+	for _, file := range candidates {
+		content, err := os.ReadFile(filepath.Join(repoRoot, file.Path))
+		if err != nil {
+			runWarnings.Warnf("skipping token budget check for %s: %v", file.Path, err)
+			fitted = append(fitted, file)
+			continue
+		}
+
+		prompt := b.BuildPrompt(string(content), collectModuleFiles(filepath.Join(repoRoot, file.Path)), backend.PromptOptions{})
+		if tokenest.Fits(tokenest.Estimate(prompt), contextWindow, reserveTokens) {
+			fitted = append(fitted, file)
+			continue
+		}
+
+		runWarnings.Warnf("dropping %s: estimated prompt does not fit a %d-token context window", file.Path, contextWindow)
+	}
+
+	return fitted, nil
+}
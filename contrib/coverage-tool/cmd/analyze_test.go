@@ -0,0 +1,106 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/selector"
+)
+
+func TestRankWithinBudgetBackfillsPastOversizedFiles(t *testing.T) {
+	dir := t.TempDir()
+	oldRepoRoot := repoRoot
+	repoRoot = dir
+	defer func() { repoRoot = oldRepoRoot }()
+
+	// huge.rs ranks first (lowest coverage) but its content is far too
+	// large to fit the context window below; ok.rs and fine.rs, each in
+	// their own directory so huge.rs's content never leaks in as module
+	// context, should backfill the limit instead of the result coming
+	// back short.
+	writeAnalyzeFixture(t, filepath.Join(dir, "huge"), "huge.rs", strings.Repeat("x", 10_000))
+	writeAnalyzeFixture(t, filepath.Join(dir, "ok"), "ok.rs", "pub fn ok() {}")
+	writeAnalyzeFixture(t, filepath.Join(dir, "fine"), "fine.rs", "pub fn fine() {}")
+
+	files := []coverage.FileStats{
+		{Path: "huge/huge.rs", LinesCovered: 0, LinesTotal: 100},
+		{Path: "ok/ok.rs", LinesCovered: 10, LinesTotal: 100},
+		{Path: "fine/fine.rs", LinesCovered: 20, LinesTotal: 100},
+	}
+
+	got, err := rankWithinBudget(files, "coverage", selector.Options{}, 2, 200, 10, "unit")
+	if err != nil {
+		t.Fatalf("rankWithinBudget returned error: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("rankWithinBudget returned %d candidates, want 2 (backfilled past huge.rs)", len(got))
+	}
+	for _, f := range got {
+		if f.Path == "huge/huge.rs" {
+			t.Errorf("rankWithinBudget kept huge/huge.rs, which cannot fit the context window")
+		}
+	}
+}
+
+func TestExportCSVWritesHeaderAndRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.csv")
+	candidates := []coverage.FileStats{
+		{Path: "storage/src/device.rs", LinesCovered: 40, LinesTotal: 100, FunctionsCovered: 4, FunctionsTotal: 5, RegionsCovered: 8, RegionsTotal: 10},
+	}
+
+	if err := exportCSV(path, candidates); err != nil {
+		t.Fatalf("exportCSV returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	want := "file,lines_covered,lines_total,functions_covered,functions_total,regions_covered,regions_total,coverage_percent\nstorage/src/device.rs,40,100,4,5,8,10,40.00\n"
+	if got := string(data); got != want {
+		t.Errorf("exportCSV wrote %q, want %q", got, want)
+	}
+}
+
+func TestExportLeaderboardCSVWritesRankedRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.csv")
+	crates := []coverage.CrateStats{
+		{Crate: "rafs", LinesCovered: 10, LinesTotal: 100},
+		{Crate: "storage", LinesCovered: 60, LinesTotal: 100},
+	}
+
+	if err := exportLeaderboardCSV(path, crates); err != nil {
+		t.Fatalf("exportLeaderboardCSV returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	want := "rank,crate,lines_covered,lines_total,coverage_percent,shortfall_percent\n" +
+		"1,rafs,10,100,10.00,90.00\n" +
+		"2,storage,60,100,60.00,40.00\n"
+	if got := string(data); got != want {
+		t.Errorf("exportLeaderboardCSV wrote %q, want %q", got, want)
+	}
+}
+
+func writeAnalyzeFixture(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("MkdirAll returned error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/checkrun"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+var (
+	annotateFile         string
+	annotateCoverageFile string
+	annotateSHA          string
+	annotateGithubOwner  string
+	annotateGithubRepo   string
+)
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate",
+	Short: "Publish a coverage-improvement check run with inline annotations on a file's uncovered functions",
+	Long: `annotate reports a target file's remaining coverage gaps as a GitHub
+check run with per-line annotations, so reviewers of the generated PR see
+coverage context inline on the diff instead of having to open a separate
+report. Unlike gate, it never fails the build; it is informational only.`,
+	RunE: runAnnotate,
+}
+
+func init() {
+	annotateCmd.Flags().StringVar(&annotateFile, "file", "", "target source file to annotate")
+	annotateCmd.Flags().StringVar(&annotateCoverageFile, "coverage-file", "coverage.json", "path to a `cargo llvm-cov --json` export")
+	annotateCmd.Flags().StringVar(&annotateSHA, "sha", "", "commit SHA to attach the check run to")
+	annotateCmd.Flags().StringVar(&annotateGithubOwner, "github-owner", "bergwolf", "GitHub organization or user owning the repository")
+	annotateCmd.Flags().StringVar(&annotateGithubRepo, "github-repo", "nydus", "GitHub repository name")
+	_ = annotateCmd.MarkFlagRequired("file")
+	_ = annotateCmd.MarkFlagRequired("sha")
+}
+
+func runAnnotate(_ *cobra.Command, _ []string) error {
+	f, err := os.Open(annotateCoverageFile)
+	if err != nil {
+		return errors.Wrap(err, "open coverage file")
+	}
+	defer f.Close()
+
+	report, err := coverage.Parse(f)
+	if err != nil {
+		return err
+	}
+
+	uncovered, err := coverage.UncoveredFunctions(report, repoRoot, annotateFile)
+	if err != nil {
+		return err
+	}
+
+	annotations := make([]checkrun.Annotation, len(uncovered))
+	for i, fn := range uncovered {
+		annotations[i] = checkrun.Annotation{
+			Path:      fn.File,
+			StartLine: fn.Line,
+			EndLine:   fn.Line,
+			Level:     checkrun.LevelWarning,
+			Message:   fmt.Sprintf("function %q has no test coverage", fn.Name),
+		}
+	}
+
+	summary := fmt.Sprintf("%s has %d uncovered function(s).", annotateFile, len(uncovered))
+
+	return checkrun.Create(annotateGithubOwner, annotateGithubRepo, annotateSHA, "coverage-improvement", checkrun.Neutral, checkrun.Output{
+		Title:       "Coverage improvement",
+		Summary:     summary,
+		Annotations: annotations,
+	})
+}
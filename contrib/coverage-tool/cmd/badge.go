@@ -0,0 +1,64 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/badge"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+var (
+	badgeCoverageFile string
+	badgeOutputDir    string
+)
+
+var badgeCmd = &cobra.Command{
+	Use:   "badge",
+	Short: "Render shields-style SVG coverage badges for the workspace and each crate",
+	Long: `badge renders one overall coverage.svg and one coverage-<crate>.svg per
+workspace crate from a cargo llvm-cov JSON export, so they can be
+committed to the repo or published to gh-pages without depending on
+shields.io at render time.`,
+	RunE: runBadge,
+}
+
+func init() {
+	badgeCmd.Flags().StringVar(&badgeCoverageFile, "coverage-file", "coverage.json", "path to a `cargo llvm-cov --json` export")
+	badgeCmd.Flags().StringVar(&badgeOutputDir, "output-dir", "badges", "directory to write the rendered SVG badges to")
+}
+
+func runBadge(_ *cobra.Command, _ []string) error {
+	files, err := loadReport(badgeCoverageFile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(badgeOutputDir, 0o755); err != nil {
+		return errors.Wrap(err, "create output dir")
+	}
+
+	overall := coverage.Report{Files: files}.Percent()
+	if err := badge.Write(filepath.Join(badgeOutputDir, "coverage.svg"), "coverage", overall); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s\n", filepath.Join(badgeOutputDir, "coverage.svg"))
+
+	for _, c := range coverage.RollupByCrate(files, func(path string) string { return cratePackageName(repoRoot, path) }) {
+		path := filepath.Join(badgeOutputDir, fmt.Sprintf("coverage-%s.svg", c.Crate))
+		if err := badge.Write(path, c.Crate, c.Percent()); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s\n", path)
+	}
+
+	return nil
+}
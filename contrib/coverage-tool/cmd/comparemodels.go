@@ -0,0 +1,231 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/backend"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/candidate"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/compare"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/cost"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+)
+
+var (
+	compareFile                string
+	compareBackend             string
+	compareCoverageFile        string
+	compareCandidates          []string
+	comparePriceTable          string
+	compareRetryMax            int
+	compareRetryDelay          time.Duration
+	compareOutputFile          string
+	compareValidateCrate       string
+	compareValidateRunner      string
+	compareSkipClippy          bool
+	compareSkipRustfmt         bool
+	compareMiri                bool
+	compareTestDenylist        []string
+	compareLogDir              string
+	compareMinAssertionDensity float64
+	compareMutants             bool
+	compareMinMutationScore    float64
+	compareCrossCheckTargets   []string
+	compareTargetDir           string
+	compareSccache             bool
+	compareIncremental         bool
+	compareMSRV                bool
+	compareCheckToolchains     []string
+	compareValidateCommand     string
+	comparePreValidateCommand  string
+	compareCommandTimeout      time.Duration
+	compareBisectFailingTests  bool
+	compareSmokeTestPaths      []string
+	compareSmokeTestCommand    string
+)
+
+var compareModelsCmd = &cobra.Command{
+	Use:   "compare-models",
+	Short: "Generate tests for a file with several providers/models and compare compile rate, coverage delta, and cost",
+	RunE:  runCompareModels,
+}
+
+func init() {
+	compareModelsCmd.Flags().StringVar(&compareFile, "file", "", "target source file to generate tests for")
+	compareModelsCmd.Flags().StringVar(&compareBackend, "backend", "unit", "generation backend to use (unit, snapshot)")
+	compareModelsCmd.Flags().StringVar(&compareCoverageFile, "coverage-file", "", "path to a `cargo llvm-cov --json` export, used as the coverage-delta baseline")
+	compareModelsCmd.Flags().StringArrayVar(&compareCandidates, "candidate", nil, "a provider/model to compare, as label=provider:model (e.g. mini=github-models:gpt-4o-mini); repeat for each candidate, at least 2 required")
+	compareModelsCmd.Flags().StringVar(&comparePriceTable, "price-table", "", "path to a JSON {model: {promptPerMillion, completionPerMillion}} price table; defaults to coverage-tool's built-in prices")
+	compareModelsCmd.Flags().IntVar(&compareRetryMax, "retry-max-attempts", 3, "max attempts (including the first) on a 429/503 from an llm provider")
+	compareModelsCmd.Flags().DurationVar(&compareRetryDelay, "retry-base-delay", time.Second, "base backoff delay before the first retry, doubling (with jitter) on each subsequent one, unless the server sends Retry-After")
+	compareModelsCmd.Flags().StringVar(&compareOutputFile, "output", "model_comparison.md", "path to write the markdown comparison report to")
+	compareModelsCmd.Flags().StringVar(&compareValidateCrate, "validate-crate", "", "Cargo package name to scope validation to via `cargo test -p`; defaults to the workspace member containing --file, falling back to the backend's whole-workspace validate command if it can't be resolved")
+	compareModelsCmd.Flags().StringVar(&compareValidateRunner, "validate-runner", "cargo", "test runner to validate generated tests with (cargo, nextest)")
+	compareModelsCmd.Flags().BoolVar(&compareSkipClippy, "skip-clippy", false, "skip the `cargo clippy --tests -- -D warnings` gate that otherwise runs, scoped to the target crate, after a candidate passes validation")
+	compareModelsCmd.Flags().BoolVar(&compareSkipRustfmt, "skip-rustfmt", false, "skip running rustfmt on the integrated file before validation")
+	compareModelsCmd.Flags().BoolVar(&compareMiri, "miri", false, "after validation passes, additionally run `cargo miri test` on candidates whose target file contains an `unsafe` block, to catch UB in generated tests exercising unsafe code paths; off by default since Miri is much slower than a normal test run")
+	compareModelsCmd.Flags().StringArrayVar(&compareTestDenylist, "test-denylist", nil, "additional regular expression a generated test is rejected for matching, beyond the built-in std::net/reqwest/absolute-path/long-sleep checks; repeat for more than one")
+	compareModelsCmd.Flags().StringVar(&compareLogDir, "log-dir", ".coverage-tool/attempts", "directory to write each candidate's attempt-N.log (every validation command's combined output) and attempt-N.diff (unified diff of the inserted test) to, so a failed run can be debugged without rerunning it; empty disables")
+	compareModelsCmd.Flags().Float64Var(&compareMinAssertionDensity, "min-assertion-density", 0, "minimum fraction of a candidate's statements that must be non-trivial assertions; a candidate below it is rejected outright as tautological before it is ever compiled (0 disables the check)")
+	compareModelsCmd.Flags().BoolVar(&compareMutants, "mutants", false, "after validation passes, additionally run `cargo mutants --file` scoped to the target file and record the mutation score; off by default since mutation testing is much slower than a normal test run")
+	compareModelsCmd.Flags().Float64Var(&compareMinMutationScore, "min-mutation-score", 0, "minimum fraction of introduced mutants a candidate's tests must catch to be accepted, only checked when --mutants is set (0 disables the check)")
+	compareModelsCmd.Flags().StringArrayVar(&compareCrossCheckTargets, "cross-check-target", nil, "additional target triple to `cargo check --tests --target` a candidate against after clippy passes, so it doesn't only compile for the host; repeat for more than one, requires the target's toolchain to be installed")
+	compareModelsCmd.Flags().StringVar(&compareTargetDir, "target-dir", "", "CARGO_TARGET_DIR to reuse across candidates instead of a fresh one per disposable worktree, so they don't recompile the crate from scratch every time; empty uses a fresh directory per candidate")
+	compareModelsCmd.Flags().BoolVar(&compareSccache, "sccache", false, "point RUSTC_WRAPPER at sccache for validation builds, so compilation artifacts are cached and reused across worktrees even without --target-dir set")
+	compareModelsCmd.Flags().BoolVar(&compareIncremental, "incremental", false, "enable incremental compilation (CARGO_INCREMENTAL=1) for validation builds, trading a larger target directory for faster rebuilds across candidates")
+	compareModelsCmd.Flags().BoolVar(&compareMSRV, "msrv", false, "additionally `cargo check --tests` a candidate against the repo's MSRV, read from Cargo.toml's rust-version or rust-toolchain.toml, so it doesn't use a newer language feature than the project supports; requires the MSRV toolchain to be installed and errors if it can't be resolved")
+	compareModelsCmd.Flags().StringArrayVar(&compareCheckToolchains, "check-toolchain", nil, "additional rustup toolchain (e.g. stable) to `cargo check --tests` a candidate against, beyond --msrv; repeat for more than one, requires the toolchain to be installed")
+	compareModelsCmd.Flags().StringVar(&compareValidateCommand, "validate-command", "", "shell command (whitespace-split, no quoting support) to validate a candidate with instead of --backend's default (e.g. a project-specific script); run from the crate root")
+	compareModelsCmd.Flags().StringVar(&comparePreValidateCommand, "pre-validate-command", "", "shell command (whitespace-split, no quoting support) to run before validation, after the candidate's fast compile check (e.g. `make smoke` for integration coverage); a non-zero exit rejects the candidate")
+	compareModelsCmd.Flags().DurationVar(&compareCommandTimeout, "command-timeout", 0, "kill and fail a candidate if any single validation command runs longer than this (0 disables the check)")
+	compareModelsCmd.Flags().BoolVar(&compareBisectFailingTests, "bisect-failing-tests", false, "retry a candidate that fails validation with the failing test(s) removed instead of discarding it outright, salvaging the tests that do pass")
+	compareModelsCmd.Flags().StringArrayVar(&compareSmokeTestPaths, "smoke-test-path", nil, "filepath.Match glob against --file; a match runs a second validation tier (--smoke-test-command, default `make smoke`) after unit tests pass, for critical paths (e.g. the FUSE server) where UT alone isn't enough confidence; repeat for more than one (unset disables the tier entirely)")
+	compareModelsCmd.Flags().StringVar(&compareSmokeTestCommand, "smoke-test-command", "", "shell command (whitespace-split, no quoting support) to run as the --smoke-test-paths tier instead of `make smoke`")
+}
+
+func runCompareModels(_ *cobra.Command, _ []string) error {
+	if len(compareCandidates) < 2 {
+		return errors.New("--candidate must be given at least twice to compare models")
+	}
+
+	candidates := make([]compare.Candidate, len(compareCandidates))
+	for i, spec := range compareCandidates {
+		c, err := compare.ParseCandidate(spec)
+		if err != nil {
+			return err
+		}
+		candidates[i] = c
+	}
+
+	content, err := os.ReadFile(compareFile)
+	if err != nil {
+		return errors.Wrap(err, "read target file")
+	}
+
+	b, err := backend.Get(compareBackend)
+	if err != nil {
+		return err
+	}
+
+	validateRunner, err := backend.ParseRunner(compareValidateRunner)
+	if err != nil {
+		return err
+	}
+
+	baseline := 0.0
+	if compareCoverageFile != "" {
+		stats, err := loadFileStats(compareCoverageFile, compareFile)
+		if err != nil {
+			return err
+		}
+		baseline = stats.Percent()
+	}
+
+	moduleEntries := collectModuleFileEntries(compareFile)
+	moduleBlocks := make([]string, len(moduleEntries))
+	for i, e := range moduleEntries {
+		moduleBlocks[i] = formatBlock(e.Name, e.Content)
+	}
+	prompt := b.BuildPrompt(string(content), strings.Join(moduleBlocks, ""), backend.PromptOptions{Async: detectAsync(string(content))})
+
+	priceTable, err := cost.LoadTable(comparePriceTable)
+	if err != nil {
+		return err
+	}
+
+	crate := resolveCrate(repoRoot, compareFile, compareValidateCrate)
+	var clippyCmd []string
+	if !compareSkipClippy {
+		clippyCmd = clippyCommand(crate)
+	}
+	var miriCmd []string
+	if compareMiri {
+		miriCmd = miriCommand(crate)
+	}
+	var mutantsCmd []string
+	if compareMutants {
+		mutantsCmd = mutantsCommand(compareFile)
+	}
+	crossCheckCmds := make([][]string, len(compareCrossCheckTargets))
+	for i, target := range compareCrossCheckTargets {
+		crossCheckCmds[i] = crossCheckCommand(crate, target)
+	}
+	toolchains := compareCheckToolchains
+	if compareMSRV {
+		msrv := readMSRV(repoRoot)
+		if msrv == "" {
+			return errors.New("--msrv given but the repo's MSRV couldn't be resolved from Cargo.toml or rust-toolchain.toml")
+		}
+		toolchains = append(toolchains[:len(toolchains):len(toolchains)], msrv)
+	}
+	toolchainCheckCmds := make([][]string, len(toolchains))
+	for i, toolchain := range toolchains {
+		toolchainCheckCmds[i] = toolchainCheckCommand(crate, toolchain)
+	}
+	features := resolveFeatures(compareFile)
+	smokeTest := smokeTestCommand(compareFile, compareSmokeTestPaths, compareSmokeTestCommand)
+	evaluator := candidate.Evaluator{
+		RepoRoot:               repoRoot,
+		CheckCommand:           withFeatures(checkCommand(crate), features),
+		ValidateCommand:        validateCommandOverride(compareValidateCommand, withFeatures(b.ValidateCommand(crate, validateRunner), features)),
+		PreValidateCommand:     splitCommand(comparePreValidateCommand),
+		SmokeTestCommand:       smokeTest,
+		ClippyCommand:          withFeatures(clippyCmd, features),
+		MiriCommand:            withFeatures(miriCmd, features),
+		CrossCheckCommands:     withFeaturesAll(crossCheckCmds, features),
+		ToolchainCheckCommands: withFeaturesAll(toolchainCheckCmds, features),
+		MutantsCommand:         withFeatures(mutantsCmd, features),
+		MinMutationScore:       compareMinMutationScore,
+		CoverageCommand:        withFeatures([]string{"cargo", "llvm-cov", "--json"}, features),
+		SkipFormat:             compareSkipRustfmt,
+		Denylist:               compareTestDenylist,
+		LogDir:                 compareLogDir,
+		MinAssertionDensity:    compareMinAssertionDensity,
+		TargetDir:              compareTargetDir,
+		Sccache:                compareSccache,
+		Incremental:            compareIncremental,
+		CommandTimeout:         compareCommandTimeout,
+		BisectFailingTests:     compareBisectFailingTests,
+	}
+
+	results := make([]compare.Result, len(candidates))
+	for i, c := range candidates {
+		provider, err := resolveProvider(c.Provider, "", "", compareRetryMax, compareRetryDelay, 0, "")
+		if err != nil {
+			results[i] = compare.Result{Candidate: c, Reason: err.Error()}
+			continue
+		}
+
+		text, usage, err := provider.Complete(c.Model, prompt, llm.DefaultOptions())
+		if err != nil {
+			results[i] = compare.Result{Candidate: c, Reason: err.Error()}
+			continue
+		}
+
+		outcome := evaluator.Evaluate(i, compareFile, text, baseline)
+		results[i] = compare.Result{
+			Candidate:       c,
+			Compiled:        outcome.Valid,
+			CoveragePercent: outcome.CoveragePercent,
+			Delta:           outcome.Delta,
+			Cost:            cost.Estimate(priceTable, c.Model, usage),
+			Reason:          outcome.Reason,
+		}
+	}
+
+	if err := compare.WriteMarkdown(compareOutputFile, compareFile, results); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote model comparison report to %s\n", compareOutputFile)
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/term"
+)
+
+var (
+	diffBeforeFile  string
+	diffAfterFile   string
+	diffThreshold   float64
+	diffFailOnRegre bool
+	diffNoColor     bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two cargo llvm-cov exports and highlight coverage regressions",
+	RunE:  runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffBeforeFile, "before", "", "coverage export to diff from")
+	diffCmd.Flags().StringVar(&diffAfterFile, "after", "", "coverage export to diff to")
+	diffCmd.Flags().Float64Var(&diffThreshold, "threshold", 0, "only print files whose |delta| is at least this many percentage points")
+	diffCmd.Flags().BoolVar(&diffFailOnRegre, "fail-on-regression", false, "exit non-zero if any file's coverage dropped by more than --threshold")
+	diffCmd.Flags().BoolVar(&diffNoColor, "no-color", false, "disable ANSI colors in the delta table, e.g. for logs that don't render them (also honors NO_COLOR)")
+}
+
+func runDiff(_ *cobra.Command, _ []string) error {
+	before, err := loadReport(diffBeforeFile)
+	if err != nil {
+		return err
+	}
+	after, err := loadReport(diffAfterFile)
+	if err != nil {
+		return err
+	}
+
+	deltas := coverage.Diff(before, after)
+	colorEnabled := term.ColorEnabled(diffNoColor)
+
+	table := term.NewTable("File", "Before", "After", "Delta")
+	regressed := false
+	for _, d := range deltas {
+		if diffThreshold > 0 && math.Abs(d.Delta()) < diffThreshold {
+			continue
+		}
+		if d.Delta() < -diffThreshold {
+			regressed = true
+		}
+		table.AddRow(d.Path, fmt.Sprintf("%.2f%%", d.BeforePercent), fmt.Sprintf("%.2f%%", d.AfterPercent), term.Delta(colorEnabled, d.Delta(), fmt.Sprintf("%+.2f%%", d.Delta())))
+	}
+	table.Render(os.Stdout)
+
+	beforeOverall := coverage.Report{Files: before}.Percent()
+	afterOverall := coverage.Report{Files: after}.Percent()
+	overallDelta := afterOverall - beforeOverall
+	fmt.Printf("\noverall: %.2f%% -> %.2f%% (%s)\n", beforeOverall, afterOverall, term.Delta(colorEnabled, overallDelta, fmt.Sprintf("%+.2f%%", overallDelta)))
+
+	if diffFailOnRegre && regressed {
+		return errors.Errorf("coverage regressed by more than %.2f%% in at least one file", diffThreshold)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/issue"
+)
+
+const digestMarker = "<!-- coverage-tool:weekly-digest -->"
+
+var (
+	digestOwner       string
+	digestRepo        string
+	digestHistoryFile string
+	digestWindow      time.Duration
+	digestDryRun      bool
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Open or update a pinned weekly coverage status issue",
+	RunE:  runDigest,
+}
+
+func init() {
+	digestCmd.Flags().StringVar(&digestOwner, "github-owner", "bergwolf", "GitHub organization or user owning the repository")
+	digestCmd.Flags().StringVar(&digestRepo, "github-repo", "nydus", "GitHub repository name")
+	digestCmd.Flags().StringVar(&digestHistoryFile, "history-file", ".coverage-tool/history.json", "path to the generation history store")
+	digestCmd.Flags().DurationVar(&digestWindow, "window", 7*24*time.Hour, "how far back to summarize attempts")
+	digestCmd.Flags().BoolVar(&digestDryRun, "dry-run", false, "print the digest instead of posting it")
+}
+
+func runDigest(_ *cobra.Command, _ []string) error {
+	hist, err := history.Open(digestHistoryFile)
+	if err != nil {
+		return err
+	}
+
+	body := buildDigest(hist, digestWindow)
+
+	if digestDryRun {
+		fmt.Println(body)
+		return nil
+	}
+
+	return issue.CreateOrUpdate(digestOwner, digestRepo, "Coverage status", body, digestMarker)
+}
+
+func buildDigest(hist *history.Store, window time.Duration) string {
+	cutoff := time.Now().Add(-window)
+
+	var succeeded, failed []string
+	for _, a := range hist.Attempts {
+		if a.Timestamp.Before(cutoff) {
+			continue
+		}
+		if a.Success {
+			succeeded = append(succeeded, a.File)
+		} else {
+			failed = append(failed, a.File)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, digestMarker)
+	fmt.Fprintln(&buf, "# Weekly coverage status")
+	fmt.Fprintf(&buf, "\n%d files improved, %d attempts failed, in the last %s.\n", len(succeeded), len(failed), window)
+
+	if len(succeeded) > 0 {
+		fmt.Fprintln(&buf, "\n## Improved")
+		for _, f := range succeeded {
+			fmt.Fprintf(&buf, "- %s\n", f)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintln(&buf, "\n## Failed")
+		for _, f := range failed {
+			fmt.Fprintf(&buf, "- %s\n", f)
+		}
+	}
+
+	return buf.String()
+}
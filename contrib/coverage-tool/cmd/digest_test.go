@@ -0,0 +1,39 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+func TestBuildDigestSummarizesRecentAttempts(t *testing.T) {
+	hist, err := history.Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := hist.Record("storage/src/device.rs", true, "", 0, 0, 0); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if err := hist.Record("rafs/src/fs.rs", false, "compile error", 0, 0, 0); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+
+	digest := buildDigest(hist, 24*time.Hour)
+
+	if !strings.Contains(digest, "storage/src/device.rs") {
+		t.Error("digest should mention the improved file")
+	}
+	if !strings.Contains(digest, "rafs/src/fs.rs") {
+		t.Error("digest should mention the failed file")
+	}
+	if !strings.Contains(digest, digestMarker) {
+		t.Error("digest should contain the pinning marker")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+)
+
+var (
+	explainCoverageFile string
+	explainModel        string
+	explainProvider     string
+	explainAPIBase      string
+	explainAPIKeyEnv    string
+	explainRetryMax     int
+	explainRetryDelay   time.Duration
+	explainAPITimeout   time.Duration
+	explainProxy        string
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <file>",
+	Short: "Explain what behaviors in a file are untested",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExplain,
+}
+
+func init() {
+	explainCmd.Flags().StringVar(&explainCoverageFile, "coverage-file", "coverage.json", "path to a `cargo llvm-cov --json` export")
+	explainCmd.Flags().StringVar(&explainModel, "model", defaultModel, "model id to use")
+	explainCmd.Flags().StringVar(&explainProvider, "llm-provider", "github-models", "chat-completion provider to explain with (github-models, ollama, llama.cpp)")
+	explainCmd.Flags().StringVar(&explainAPIBase, "api-base", "", "base URL of an OpenAI-compatible chat completions endpoint (e.g. a vLLM or LiteLLM gateway); overrides --llm-provider when set")
+	explainCmd.Flags().StringVar(&explainAPIKeyEnv, "api-key-env", "", "environment variable holding the bearer token for --api-base, if it requires one")
+	explainCmd.Flags().IntVar(&explainRetryMax, "retry-max-attempts", 3, "max attempts (including the first) on a 429/503 from the llm provider")
+	explainCmd.Flags().DurationVar(&explainRetryDelay, "retry-base-delay", time.Second, "base backoff delay before the first retry, doubling (with jitter) on each subsequent one, unless the server sends Retry-After")
+	explainCmd.Flags().DurationVar(&explainAPITimeout, "api-timeout", 0, "http client timeout for llm provider requests (0 uses the provider's own default, e.g. 120s for hosted APIs or 300s for ollama)")
+	explainCmd.Flags().StringVar(&explainProxy, "proxy", "", "proxy URL for llm provider requests, overriding the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables")
+}
+
+func runExplain(_ *cobra.Command, args []string) error {
+	target := args[0]
+
+	stats, err := loadFileStats(explainCoverageFile, target)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(target)
+	if err != nil {
+		return errors.Wrap(err, "read target file")
+	}
+
+	prompt := fmt.Sprintf(
+		"The file %s is at %.2f%% line coverage (%d/%d lines covered).\n\n%s\n\n"+
+			"Summarize, in plain prose, what behaviors in this file are untested and what kinds of "+
+			"tests would be needed to cover them. Do not write test code, just the summary.",
+		target, stats.Percent(), stats.LinesCovered, stats.LinesTotal, content,
+	)
+
+	provider, err := resolveProvider(explainProvider, explainAPIBase, explainAPIKeyEnv, explainRetryMax, explainRetryDelay, explainAPITimeout, explainProxy)
+	if err != nil {
+		return err
+	}
+
+	explanation, _, err := provider.Complete(explainModel, prompt, llm.DefaultOptions())
+	if err != nil {
+		return errors.Wrap(err, "explain coverage gap")
+	}
+
+	fmt.Println(explanation)
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/failurereport"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+var (
+	failuresHistoryFile string
+	failuresOutputFile  string
+)
+
+var failuresCmd = &cobra.Command{
+	Use:   "failures",
+	Short: "Report every rejected generation attempt, classified by why it failed",
+	Long: `failures reads the generation history store populated by generate and
+report --history-file and writes a markdown report enumerating every
+rejected attempt, classified as a compile error, a failing test, a flaky
+test, or insufficient coverage delta, so maintainers can see which
+failure mode is most common and use that to steer prompt changes.`,
+	RunE: runFailures,
+}
+
+func init() {
+	failuresCmd.Flags().StringVar(&failuresHistoryFile, "history-file", ".coverage-tool/history.json", "path to the generation history store to read attempts from")
+	failuresCmd.Flags().StringVar(&failuresOutputFile, "output", "failures_report.md", "path to write the failures report to")
+}
+
+func runFailures(_ *cobra.Command, _ []string) error {
+	hist, err := history.Open(failuresHistoryFile)
+	if err != nil {
+		return err
+	}
+
+	if err := failurereport.WriteMarkdown(failuresOutputFile, hist.Attempts); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote failures report to %s\n", failuresOutputFile)
+	return nil
+}
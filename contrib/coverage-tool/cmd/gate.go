@@ -0,0 +1,71 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/checkrun"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+var (
+	gateCoverageFile string
+	gateMinCoverage  float64
+	gateWarnCoverage float64
+	gateSHA          string
+	gateGithubOwner  string
+	gateGithubRepo   string
+	gateCheckRunName string
+)
+
+var gateCmd = &cobra.Command{
+	Use:   "gate",
+	Short: "Publish a GitHub check run gating a commit on coverage thresholds",
+	Long: `gate reports overall coverage as a GitHub check run rather than only
+a workflow step's exit code, so the result is visible on the PR with
+detailed output and survives being re-run independently of the rest of
+the workflow. Coverage below --min-coverage fails the check; coverage
+below --warn-coverage but above the minimum is reported neutral, so it
+is visible without blocking the merge. If --config points at an org-wide
+policy and --min-coverage/--warn-coverage are left unset, the policy's
+thresholds are used instead of 0.`,
+	RunE: runGate,
+}
+
+func init() {
+	gateCmd.Flags().StringVar(&gateCoverageFile, "coverage-file", "coverage.json", "path to a `cargo llvm-cov --json` export")
+	gateCmd.Flags().Float64Var(&gateMinCoverage, "min-coverage", 0, "overall coverage percentage below which the check fails")
+	gateCmd.Flags().Float64Var(&gateWarnCoverage, "warn-coverage", 0, "overall coverage percentage below which the check is neutral instead of successful")
+	gateCmd.Flags().StringVar(&gateSHA, "sha", "", "commit SHA to attach the check run to")
+	gateCmd.Flags().StringVar(&gateGithubOwner, "github-owner", "bergwolf", "GitHub organization or user owning the repository")
+	gateCmd.Flags().StringVar(&gateGithubRepo, "github-repo", "nydus", "GitHub repository name")
+	gateCmd.Flags().StringVar(&gateCheckRunName, "name", "coverage-tool", "name of the check run to publish")
+	_ = gateCmd.MarkFlagRequired("sha")
+}
+
+func runGate(cmd *cobra.Command, _ []string) error {
+	if cfg != nil {
+		applyConfigDefaultFloat(cmd, "min-coverage", &gateMinCoverage, cfg.MinCoverage)
+		applyConfigDefaultFloat(cmd, "warn-coverage", &gateWarnCoverage, cfg.WarnCoverage)
+	}
+
+	files, err := loadReport(gateCoverageFile)
+	if err != nil {
+		return err
+	}
+
+	percent := (coverage.Report{Files: files}).Percent()
+	conclusion := checkrun.Evaluate(percent, gateMinCoverage, gateWarnCoverage)
+
+	summary := fmt.Sprintf("Overall coverage is %.2f%% (minimum %.2f%%, warn below %.2f%%).", percent, gateMinCoverage, gateWarnCoverage)
+
+	return checkrun.Create(gateGithubOwner, gateGithubRepo, gateSHA, gateCheckRunName, conclusion, checkrun.Output{
+		Title:   "Coverage gate: " + string(conclusion),
+		Summary: summary,
+	})
+}
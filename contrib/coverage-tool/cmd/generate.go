@@ -0,0 +1,1192 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/audit"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/backend"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/candidate"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/chunk"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/cost"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/embed"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/exemplar"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/existingtests"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/issue"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/prompt"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/ratelimit"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/recommend"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/redact"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/regression"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/tokenest"
+)
+
+const (
+	defaultModel                = "gpt-4o-mini"
+	failureCooldown             = 24 * time.Hour
+	maxFailuresBeforeCooldown   = 3
+	maxAttemptsBeforeEscalation = 2
+)
+
+var (
+	generateFile                string
+	generateModel               string
+	generateHistoryFile         string
+	generateBackend             string
+	generateProvider            string
+	generateAPIBase             string
+	generateAPIKeyEnv           string
+	generateRetryMax            int
+	generateRetryDelay          time.Duration
+	generateRateLimitFile       string
+	generateRequestsPerMinute   int
+	generateTokensPerMinute     int
+	generateContextWindow       int
+	generateReserveTokens       int
+	generateCostFile            string
+	generatePriceTable          string
+	generateMaxCost             float64
+	generatePromptTemplate      string
+	generateCoverageFile        string
+	generateFromCommit          string
+	generateFromIssue           string
+	generateFromFile            string
+	generatePublicOnly          bool
+	generateFewShotExamples     int
+	generateSystemPrompt        string
+	generateTemperature         float64
+	generateTopP                float64
+	generateMaxTokens           int
+	generateCandidates          int
+	generateCandidatesFile      string
+	generateAuditLog            string
+	generateEnableFileTool      bool
+	generateChunkThreshold      int
+	generateChunkGroupSize      int
+	generateModuleContextTopK   int
+	generateEmbeddingsProvider  string
+	generateEmbeddingsModel     string
+	generateRedactSecrets       bool
+	generateEscalationModel     string
+	generateApplyBestEffort     bool
+	generateMaxDuration         time.Duration
+	generateAPITimeout          time.Duration
+	generateProxy               string
+	generateValidateCrate       string
+	generateValidateRunner      string
+	generateValidateCommand     string
+	generatePreValidateCommand  string
+	generateCommandTimeout      time.Duration
+	generateBisectFailingTests  bool
+	generateSmokeTestPaths      []string
+	generateSmokeTestCommand    string
+	generateSkipClippy          bool
+	generateSkipRustfmt         bool
+	generateMinDelta            float64
+	generateMinAssertionDensity float64
+	generateMutants             bool
+	generateMinMutationScore    float64
+	generateCrossCheckTargets   []string
+	generateMSRV                bool
+	generateCheckToolchains     []string
+	generateMiri                bool
+	generateTestDenylist        []string
+	generateLogDir              string
+	generateTargetDir           string
+	generateSccache             bool
+	generateIncremental         bool
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate tests for a poorly-covered file using a language model",
+	RunE:  runGenerate,
+}
+
+func init() {
+	generateCmd.Flags().StringVar(&generateFile, "file", "", "target source file to generate tests for")
+	generateCmd.Flags().StringVar(&generateModel, "model", defaultModel, "model id to use")
+	generateCmd.Flags().StringVar(&generateHistoryFile, "history-file", ".coverage-tool/history.json", "path to the generation history store")
+	generateCmd.Flags().StringVar(&generateBackend, "backend", "unit", "generation backend to use (unit, snapshot); defaults to the --config policy's provider, if any, when unset")
+	generateCmd.Flags().StringVar(&generateProvider, "llm-provider", "github-models", "chat-completion provider to generate with (github-models, ollama, llama.cpp)")
+	generateCmd.Flags().StringVar(&generateAPIBase, "api-base", "", "base URL of an OpenAI-compatible chat completions endpoint (e.g. a vLLM or LiteLLM gateway); overrides --llm-provider when set")
+	generateCmd.Flags().StringVar(&generateAPIKeyEnv, "api-key-env", "", "environment variable holding the bearer token for --api-base, if it requires one")
+	generateCmd.Flags().IntVar(&generateRetryMax, "retry-max-attempts", 3, "max attempts (including the first) on a 429/503 from the llm provider")
+	generateCmd.Flags().DurationVar(&generateRetryDelay, "retry-base-delay", time.Second, "base backoff delay before the first retry, doubling (with jitter) on each subsequent one, unless the server sends Retry-After")
+	generateCmd.Flags().StringVar(&generateRateLimitFile, "rate-limit-file", ".coverage-tool/ratelimit.json", "path to the rate limiter state, shared across concurrent generate runs")
+	generateCmd.Flags().IntVar(&generateRequestsPerMinute, "requests-per-min", 0, "max llm requests per minute across all generate runs sharing --rate-limit-file (0 disables the check)")
+	generateCmd.Flags().IntVar(&generateTokensPerMinute, "tokens-per-min", 0, "max estimated prompt tokens per minute across all generate runs sharing --rate-limit-file (0 disables the check)")
+	generateCmd.Flags().IntVar(&generateContextWindow, "context-window", 0, "model context window in tokens; module-context files are progressively dropped, largest first, until the prompt fits (0 disables the check)")
+	generateCmd.Flags().IntVar(&generateReserveTokens, "reserve-tokens", 1024, "tokens to reserve for the completion when checking --context-window")
+	generateCmd.Flags().StringVar(&generateCostFile, "cost-file", ".coverage-tool/cost.json", "path to the accumulated cost ledger, shared across generate runs")
+	generateCmd.Flags().StringVar(&generatePriceTable, "price-table", "", "path to a JSON {model: {promptPerMillion, completionPerMillion}} price table; defaults to coverage-tool's built-in prices")
+	generateCmd.Flags().Float64Var(&generateMaxCost, "max-cost", 0, "abort once --cost-file's total estimated spend reaches this many US dollars (0 disables the check)")
+	generateCmd.Flags().StringVar(&generatePromptTemplate, "prompt-template", "", "path to a text/template file to render the prompt from, instead of --backend's built-in one; see pkg/prompt.Data for the available variables")
+	generateCmd.Flags().StringVar(&generateCoverageFile, "coverage-file", "", "path to a `cargo llvm-cov --json` export, used to populate --prompt-template's .Stats and .UncoveredFunctionCount")
+	generateCmd.Flags().StringVar(&generateFromCommit, "from-commit", "", "generate a regression test from a bug-fix commit sha instead of the configured backend")
+	generateCmd.Flags().StringVar(&generateFromIssue, "from-issue", "", "generate a reproducing test from a GitHub issue URL instead of the configured backend")
+	generateCmd.Flags().StringVar(&generateFromFile, "from-file", "", "skip the llm provider entirely and run the same integrate/validate/report pipeline against a human-written or externally generated test file at this path; useful in air-gapped environments")
+	generateCmd.Flags().BoolVar(&generatePublicOnly, "public-only", false, "instruct the model to only test pub items of the target file")
+	generateCmd.Flags().IntVar(&generateFewShotExamples, "few-shot-examples", 0, "include up to this many highly covered, already-tested sibling files from the target's crate as style exemplars (requires --coverage-file; 0 disables)")
+	generateCmd.Flags().StringVar(&generateSystemPrompt, "system-prompt", "", "system prompt to prepend to the model request, overriding the provider's default")
+	generateCmd.Flags().Float64Var(&generateTemperature, "temperature", llm.DefaultOptions().Temperature, "sampling temperature; lower is more deterministic, useful for reproducible CI runs")
+	generateCmd.Flags().Float64Var(&generateTopP, "top-p", llm.DefaultOptions().TopP, "nucleus sampling probability mass")
+	generateCmd.Flags().IntVar(&generateMaxTokens, "max-tokens", 0, "max tokens in the generated completion (0 leaves it to the provider's default)")
+	generateCmd.Flags().IntVar(&generateCandidates, "candidates", 1, "request this many completions, validate each in an isolated git worktree, and keep the one with the best coverage delta")
+	generateCmd.Flags().StringVar(&generateCandidatesFile, "candidates-file", ".coverage-tool/candidates.json", "path to the candidate run artifacts, appended to on every --candidates > 1 run")
+	generateCmd.Flags().StringVar(&generateAuditLog, "audit-log", ".coverage-tool/audit.jsonl", "path to the append-only JSONL audit log of every prompt, response, model, and token usage sent to the llm provider")
+	generateCmd.Flags().BoolVar(&generateEnableFileTool, "enable-file-tool", false, "let the model request additional repository files by path mid-generation via a read_file tool call, served with allowlisting to paths inside the repository (forces non-streaming completion)")
+	generateCmd.Flags().IntVar(&generateChunkThreshold, "chunk-threshold-lines", 0, "split generation into one completion per --chunk-group-size functions when the target file exceeds this many lines, to avoid truncated completions on very large files (0 disables)")
+	generateCmd.Flags().IntVar(&generateChunkGroupSize, "chunk-group-size", 5, "functions per completion request once --chunk-threshold-lines is exceeded")
+	generateCmd.Flags().IntVar(&generateModuleContextTopK, "module-context-top-k", 0, "rank sibling module-context files by embedding relevance to the target file and keep only the top K (0 keeps every sibling file, coverage-tool's previous behavior)")
+	generateCmd.Flags().StringVar(&generateEmbeddingsProvider, "embeddings-provider", "local", "embedder used by --module-context-top-k: \"local\" hashes token frequencies with no network access, \"api\" calls --api-base's /embeddings endpoint")
+	generateCmd.Flags().StringVar(&generateEmbeddingsModel, "embeddings-model", "text-embedding-3-small", "embedding model id to request when --embeddings-provider=api")
+	generateCmd.Flags().BoolVar(&generateRedactSecrets, "redact-secrets", true, "scan the target file, module context, and any diff/issue text for obvious secrets (private keys, AWS/GitHub tokens, API key assignments) and replace them with a placeholder before sending anything to the llm provider, warning about what was found")
+	generateCmd.Flags().StringVar(&generateEscalationModel, "escalation-model", "", "model id to retry with, after --model's output fails validation twice, validating the same way --candidates does (forces non-streaming completion); empty disables escalation and skips validating a single-shot generation")
+	generateCmd.Flags().BoolVar(&generateApplyBestEffort, "apply-best-effort", false, "if every retry and escalation attempt still fails validation, apply the attempt with the fewest failing tests (then highest coverage) with its failing tests stripped, instead of failing the run; the result is not re-validated, so review it before trusting it")
+	generateCmd.Flags().DurationVar(&generateMaxDuration, "max-duration", 0, "stop the retry/escalation loop and report failure (applying --apply-best-effort if set) once this much wall-clock time has elapsed since the first attempt, instead of a scheduled CI job's own timeout killing the run without producing artifacts (0 disables the check)")
+	generateCmd.Flags().DurationVar(&generateAPITimeout, "api-timeout", 0, "http client timeout for llm provider requests (0 uses the provider's own default, e.g. 120s for hosted APIs or 300s for ollama)")
+	generateCmd.Flags().StringVar(&generateProxy, "proxy", "", "proxy URL for llm provider requests, overriding the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables")
+	generateCmd.Flags().StringVar(&generateValidateCrate, "validate-crate", "", "Cargo package name to scope validation to via `cargo test -p`; defaults to the workspace member containing --file, falling back to the backend's whole-workspace validate command if it can't be resolved")
+	generateCmd.Flags().StringVar(&generateValidateRunner, "validate-runner", "cargo", "test runner to validate generated tests with (cargo, nextest); nextest reports exactly which test failed, fed back into the next regeneration attempt")
+	generateCmd.Flags().StringVar(&generateValidateCommand, "validate-command", "", "shell command (whitespace-split, no quoting support) to validate a candidate with instead of --backend's default (e.g. a project-specific script); run from the crate root")
+	generateCmd.Flags().StringVar(&generatePreValidateCommand, "pre-validate-command", "", "shell command (whitespace-split, no quoting support) to run before validation, after --file's fast compile check (e.g. `make smoke` for integration coverage); a non-zero exit rejects the candidate")
+	generateCmd.Flags().DurationVar(&generateCommandTimeout, "command-timeout", 0, "kill and fail the candidate if any single validation command (check, pre-validate, validate, clippy, cross-check, toolchain-check, miri, mutants, coverage) runs longer than this (0 disables the check)")
+	generateCmd.Flags().BoolVar(&generateBisectFailingTests, "bisect-failing-tests", false, "retry a candidate that fails validation with the failing test(s) removed instead of discarding it outright, salvaging the tests that do pass")
+	generateCmd.Flags().StringArrayVar(&generateSmokeTestPaths, "smoke-test-path", nil, "filepath.Match glob against --file; a match runs a second validation tier (--smoke-test-command, default `make smoke`) after unit tests pass, for critical paths (e.g. the FUSE server) where UT alone isn't enough confidence; repeat for more than one (unset disables the tier entirely)")
+	generateCmd.Flags().StringVar(&generateSmokeTestCommand, "smoke-test-command", "", "shell command (whitespace-split, no quoting support) to run as the --smoke-test-paths tier instead of `make smoke`")
+	generateCmd.Flags().BoolVar(&generateSkipClippy, "skip-clippy", false, "skip the `cargo clippy --tests -- -D warnings` gate that otherwise runs, scoped to the target crate, after a candidate passes validation, rejecting it if clippy warns; our CI blocks on clippy so this is on by default")
+	generateCmd.Flags().BoolVar(&generateSkipRustfmt, "skip-rustfmt", false, "skip running rustfmt on the integrated file before validation; on by default so inconsistently indented generated tests don't fail fmt-check in CI later")
+	generateCmd.Flags().Float64Var(&generateMinDelta, "min-delta", 0, "minimum coverage-percentage-point improvement over --coverage-file's baseline a candidate must reach to be accepted; a compiling but useless test is rejected and regenerated instead")
+	generateCmd.Flags().Float64Var(&generateMinAssertionDensity, "min-assertion-density", 0, "minimum fraction of a candidate's statements that must be non-trivial assertions (assert!, assert_eq!, assert_ne!; assert!(true) doesn't count); a candidate below it is rejected outright as tautological before it is ever compiled (0 disables the check)")
+	generateCmd.Flags().BoolVar(&generateMutants, "mutants", false, "after validation passes, additionally run `cargo mutants --file` scoped to the target file and record the mutation score; off by default since mutation testing is much slower than a normal test run")
+	generateCmd.Flags().Float64Var(&generateMinMutationScore, "min-mutation-score", 0, "minimum fraction of introduced mutants a candidate's tests must catch to be accepted, only checked when --mutants is set (0 disables the check)")
+	generateCmd.Flags().StringArrayVar(&generateCrossCheckTargets, "cross-check-target", nil, "additional target triple (e.g. x86_64-unknown-linux-musl, aarch64-unknown-linux-gnu) to `cargo check --tests --target` a candidate against after clippy passes, so it doesn't only compile for the host; repeat for more than one, requires the target's toolchain to be installed")
+	generateCmd.Flags().BoolVar(&generateMSRV, "msrv", false, "additionally `cargo check --tests` a candidate against the repo's MSRV, read from Cargo.toml's rust-version or rust-toolchain.toml, so it doesn't use a newer language feature than the project supports; requires the MSRV toolchain to be installed and errors if it can't be resolved")
+	generateCmd.Flags().StringArrayVar(&generateCheckToolchains, "check-toolchain", nil, "additional rustup toolchain (e.g. stable) to `cargo check --tests` a candidate against, beyond --msrv; repeat for more than one, requires the toolchain to be installed")
+	generateCmd.Flags().BoolVar(&generateMiri, "miri", false, "after validation passes, additionally run `cargo miri test` on candidates whose target file contains an `unsafe` block, to catch UB in generated tests exercising unsafe code paths; off by default since Miri is much slower than a normal test run")
+	generateCmd.Flags().StringArrayVar(&generateTestDenylist, "test-denylist", nil, "additional regular expression a generated test is rejected for matching, beyond the built-in std::net/reqwest/absolute-path/long-sleep checks; repeat for more than one")
+	generateCmd.Flags().StringVar(&generateLogDir, "log-dir", ".coverage-tool/attempts", "directory to write each attempt's attempt-N.log (every validation command's combined output) and attempt-N.diff (unified diff of the inserted test) to, so a failed run can be debugged without rerunning it; empty disables")
+	generateCmd.Flags().StringVar(&generateTargetDir, "target-dir", "", "CARGO_TARGET_DIR to reuse across retry/escalation attempts instead of a fresh one per disposable worktree, so they don't recompile the crate from scratch every time; empty uses a fresh directory per attempt")
+	generateCmd.Flags().BoolVar(&generateSccache, "sccache", false, "point RUSTC_WRAPPER at sccache for validation builds, so compilation artifacts are cached and reused across worktrees even without --target-dir set")
+	generateCmd.Flags().BoolVar(&generateIncremental, "incremental", false, "enable incremental compilation (CARGO_INCREMENTAL=1) for validation builds, trading a larger target directory for faster rebuilds across retry attempts")
+	_ = generateCmd.MarkFlagRequired("file")
+}
+
+// generationOptions builds the llm.Options for a generate run from its
+// --system-prompt/--temperature/--top-p/--max-tokens/--enable-file-tool
+// flags.
+func generationOptions() llm.Options {
+	opts := llm.Options{
+		SystemPrompt: generateSystemPrompt,
+		Temperature:  generateTemperature,
+		TopP:         generateTopP,
+		MaxTokens:    generateMaxTokens,
+	}
+	if generateEnableFileTool {
+		opts.Tools = []llm.Tool{fileTool}
+		opts.ToolHandler = serveFileTool(repoRoot)
+	}
+	return opts
+}
+
+// clippyCommandUnlessSkipped returns clippyCommand(crate), or nil if
+// --skip-clippy was given, in which case candidate.Evaluator skips the gate
+// entirely.
+func clippyCommandUnlessSkipped(crate string) []string {
+	if generateSkipClippy {
+		return nil
+	}
+	return clippyCommand(crate)
+}
+
+// miriCommandIfEnabled returns miriCommand(crate), or nil unless --miri was
+// given, in which case candidate.Evaluator never runs Miri regardless of
+// whether the target file contains an unsafe block.
+func miriCommandIfEnabled(crate string) []string {
+	if !generateMiri {
+		return nil
+	}
+	return miriCommand(crate)
+}
+
+// mutantsCommandIfEnabled returns mutantsCommand(file), or nil unless
+// --mutants was given, in which case candidate.Evaluator never runs
+// cargo-mutants.
+func mutantsCommandIfEnabled(file string) []string {
+	if !generateMutants {
+		return nil
+	}
+	return mutantsCommand(file)
+}
+
+// crossCheckCommands builds one crossCheckCommand(crate, target) per
+// --cross-check-target given, or nil if none were.
+func crossCheckCommands(crate string) [][]string {
+	if len(generateCrossCheckTargets) == 0 {
+		return nil
+	}
+	commands := make([][]string, len(generateCrossCheckTargets))
+	for i, target := range generateCrossCheckTargets {
+		commands[i] = crossCheckCommand(crate, target)
+	}
+	return commands
+}
+
+// toolchainCheckCommands builds one toolchainCheckCommand(crate, toolchain)
+// per --check-toolchain given, plus one for the repo's MSRV if --msrv was
+// given, erroring if --msrv can't resolve one.
+func toolchainCheckCommands(crate string) ([][]string, error) {
+	toolchains := slices.Clone(generateCheckToolchains)
+	if generateMSRV {
+		msrv := readMSRV(repoRoot)
+		if msrv == "" {
+			return nil, errors.New("--msrv given but the repo's MSRV couldn't be resolved from Cargo.toml or rust-toolchain.toml")
+		}
+		toolchains = append(toolchains, msrv)
+	}
+
+	if len(toolchains) == 0 {
+		return nil, nil
+	}
+	commands := make([][]string, len(toolchains))
+	for i, toolchain := range toolchains {
+		commands[i] = toolchainCheckCommand(crate, toolchain)
+	}
+	return commands, nil
+}
+
+// redactSecrets scans text for obvious secrets and replaces them with a
+// placeholder when --redact-secrets is enabled, warning about anything it
+// found so a redaction is visible to the operator rather than silent.
+func redactSecrets(text string) string {
+	if !generateRedactSecrets {
+		return text
+	}
+	redacted, matches := redact.Redact(text)
+	for _, m := range matches {
+		runWarnings.Warnf("redacted %d occurrence(s) of a likely %s before sending it to the llm provider", m.Count, m.Name)
+	}
+	return redacted
+}
+
+// fileTool lets the model request the contents of another file in the
+// repository mid-generation, for better-informed tests of cross-module
+// code, instead of relying solely on the module context collected upfront.
+var fileTool = llm.Tool{
+	Name:        "read_file",
+	Description: "Read the contents of a file in this repository by its path relative to the repository root.",
+	Parameters: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"path": {"type": "string", "description": "file path relative to the repository root, e.g. storage/src/device.rs"}
+		},
+		"required": ["path"]
+	}`),
+}
+
+// serveFileTool returns a llm.ToolHandler for fileTool that serves reads
+// allowlisted to files inside repoRoot, so a model can't be tricked into
+// exfiltrating files elsewhere on the machine via a "../" path.
+func serveFileTool(repoRoot string) llm.ToolHandler {
+	return func(name string, arguments json.RawMessage) (string, error) {
+		if name != fileTool.Name {
+			return fmt.Sprintf("error: unknown tool %q", name), nil
+		}
+
+		var args struct {
+			Path string `json:"path"`
+		}
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return fmt.Sprintf("error: invalid arguments: %v", err), nil
+		}
+
+		requested := filepath.Join(repoRoot, args.Path)
+		if rel, err := filepath.Rel(repoRoot, requested); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Sprintf("error: %q is outside the repository", args.Path), nil
+		}
+
+		content, err := os.ReadFile(requested)
+		if err != nil {
+			return fmt.Sprintf("error: %v", err), nil
+		}
+		return string(content), nil
+	}
+}
+
+func runGenerate(cmd *cobra.Command, _ []string) error {
+	if cfg != nil {
+		applyConfigDefault(cmd, "backend", &generateBackend, cfg.Provider)
+		applyConfigDefault(cmd, "validate-command", &generateValidateCommand, cfg.ValidateCommand)
+		applyConfigDefault(cmd, "validate-runner", &generateValidateRunner, cfg.ValidateRunner)
+		applyConfigDefaultFloat(cmd, "min-delta", &generateMinDelta, cfg.MinDelta)
+	}
+
+	provider, err := resolveProvider(generateProvider, generateAPIBase, generateAPIKeyEnv, generateRetryMax, generateRetryDelay, generateAPITimeout, generateProxy)
+	if err != nil {
+		return err
+	}
+
+	auditLog, err := audit.Open(generateAuditLog)
+	if err != nil {
+		return err
+	}
+	defer auditLog.Close()
+
+	if generateFromCommit != "" {
+		return runGenerateFromCommit(provider, auditLog)
+	}
+	if generateFromIssue != "" {
+		return runGenerateFromIssue(provider, auditLog)
+	}
+	if generateFromFile != "" {
+		return runGenerateFromFile(auditLog)
+	}
+
+	hist, err := history.Open(generateHistoryFile)
+	if err != nil {
+		return err
+	}
+
+	if hist.InCooldown(generateFile, maxFailuresBeforeCooldown, failureCooldown) {
+		runWarnings.Warnf("skipping %s: %d consecutive generation failures within the last %s", generateFile, maxFailuresBeforeCooldown, failureCooldown)
+		return nil
+	}
+
+	budget, err := cost.OpenBudget(generateCostFile, generateMaxCost)
+	if err != nil {
+		return err
+	}
+	if budget.Exceeded() {
+		runWarnings.Warnf("skipping %s: --cost-file has already reached the $%.2f --max-cost budget", generateFile, generateMaxCost)
+		return nil
+	}
+
+	b, err := backend.Get(generateBackend)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(generateFile)
+	if err != nil {
+		return errors.Wrap(err, "read target file")
+	}
+	redactedContent := redactSecrets(string(content))
+
+	existingTests := existingtests.Extract(redactedContent)
+	existingTestNames := make([]string, len(existingTests))
+	for i, t := range existingTests {
+		existingTestNames[i] = t.Name
+	}
+
+	moduleEntries := collectModuleFileEntries(generateFile)
+	if generateModuleContextTopK > 0 && len(moduleEntries) > generateModuleContextTopK {
+		moduleEntries, err = rankModuleContext(moduleEntries, redactedContent)
+		if err != nil {
+			return err
+		}
+	}
+	moduleBlocks := make([]string, len(moduleEntries))
+	for i, e := range moduleEntries {
+		moduleBlocks[i] = redactSecrets(formatBlock(e.Name, e.Content))
+	}
+
+	var exemplarBlocks []string
+	if generateFewShotExamples > 0 {
+		if generateCoverageFile == "" {
+			runWarnings.Warnf("--few-shot-examples requires --coverage-file to rank sibling files by coverage; skipping")
+		} else {
+			covered, err := loadReport(generateCoverageFile)
+			if err != nil {
+				return err
+			}
+			exemplarBlocks = formatExemplarBlocks(exemplar.Find(repoRoot, generateFile, covered, generateFewShotExamples))
+			for i, blk := range exemplarBlocks {
+				exemplarBlocks[i] = redactSecrets(blk)
+			}
+		}
+	}
+
+	build := func(moduleContext string) string {
+		return b.BuildPrompt(redactedContent, moduleContext, backend.PromptOptions{PublicOnly: generatePublicOnly, Exemplars: exemplarBlocks, ExistingTests: existingTestNames, Async: detectAsync(redactedContent)})
+	}
+	if generatePromptTemplate != "" {
+		stats := coverage.FileStats{Path: generateFile}
+		if generateCoverageFile != "" {
+			stats, err = loadFileStats(generateCoverageFile, generateFile)
+			if err != nil {
+				return err
+			}
+		}
+		build = func(moduleContext string) string {
+			data := prompt.Data{
+				FileContent:            redactedContent,
+				ModuleContext:          moduleContext,
+				Stats:                  stats,
+				UncoveredFunctionCount: stats.FunctionsTotal - stats.FunctionsCovered,
+				PublicOnly:             generatePublicOnly,
+				Exemplars:              exemplarBlocks,
+				ExistingTests:          existingTestNames,
+			}
+			rendered, err := prompt.Render(generatePromptTemplate, data)
+			if err != nil {
+				runWarnings.Warnf("failed to render --prompt-template, falling back to --backend's built-in prompt: %v", err)
+				return b.BuildPrompt(redactedContent, moduleContext, backend.PromptOptions{PublicOnly: generatePublicOnly, Exemplars: exemplarBlocks, ExistingTests: existingTestNames, Async: detectAsync(redactedContent)})
+			}
+			return rendered
+		}
+	}
+
+	limiter, err := ratelimit.Open(generateRateLimitFile, generateRequestsPerMinute, generateTokensPerMinute)
+	if err != nil {
+		return err
+	}
+
+	priceTable, err := cost.LoadTable(generatePriceTable)
+	if err != nil {
+		return err
+	}
+
+	streaming := false
+	var generated string
+	var usage llm.Usage
+	usedModel := generateModel
+	lineCount := strings.Count(string(content), "\n") + 1
+	if generateChunkThreshold > 0 && lineCount > generateChunkThreshold {
+		generated, usage, err = runChunkedGeneration(provider, limiter, redactedContent, strings.Join(moduleBlocks, ""), auditLog)
+		if err != nil {
+			if recordErr := hist.Record(generateFile, false, err.Error(), usage.PromptTokens, usage.CompletionTokens, cost.Estimate(priceTable, usedModel, usage)); recordErr != nil {
+				runWarnings.Warnf("failed to record generation history: %v", recordErr)
+			}
+			return errors.Wrap(err, "generate tests")
+		}
+	} else {
+		generatedPrompt := trimModuleContextToFit(build, moduleBlocks, generateContextWindow, generateReserveTokens)
+
+		if err := limiter.Wait(tokenest.Estimate(generatedPrompt)); err != nil {
+			return errors.Wrap(err, "wait for rate limit budget")
+		}
+
+		switch {
+		case generateCandidates > 1:
+			generated, usage, err = runCandidates(provider, limiter, generatedPrompt, b, auditLog)
+		case generateEscalationModel != "":
+			generated, usage, usedModel, err = runWithEscalation(provider, limiter, generatedPrompt, b, auditLog)
+		default:
+			if sp, ok := provider.(llm.StreamingProvider); ok && !generateEnableFileTool {
+				streaming = true
+				generated, usage, err = sp.CompleteStream(generateModel, generatedPrompt, generationOptions(), func(chunk string) { fmt.Print(chunk) })
+			} else {
+				generated, usage, err = provider.Complete(generateModel, generatedPrompt, generationOptions())
+			}
+			if err == nil {
+				if recErr := auditLog.Record(generateFile, generateModel, generatedPrompt, generated, usage); recErr != nil {
+					runWarnings.Warnf("failed to record audit log: %v", recErr)
+				}
+			}
+		}
+		if err != nil {
+			if recordErr := hist.Record(generateFile, false, err.Error(), usage.PromptTokens, usage.CompletionTokens, cost.Estimate(priceTable, usedModel, usage)); recordErr != nil {
+				runWarnings.Warnf("failed to record generation history: %v", recordErr)
+			}
+			return errors.Wrap(err, "generate tests")
+		}
+	}
+
+	if dropped, names := existingtests.Filter(generated, existingTests); len(names) > 0 {
+		generated = dropped
+		runWarnings.Warnf("dropped %d generated test(s) that duplicate an existing test by name: %s", len(names), strings.Join(names, ", "))
+	}
+
+	spend := cost.Estimate(priceTable, usedModel, usage)
+	if err := hist.Record(generateFile, true, "", usage.PromptTokens, usage.CompletionTokens, spend); err != nil {
+		runWarnings.Warnf("failed to record generation history: %v", err)
+	}
+
+	if err := budget.Record(generateFile, usedModel, spend); err != nil {
+		runWarnings.Warnf("failed to record cost ledger: %v", err)
+	}
+	fmt.Printf("estimated cost: $%.4f (total spent: $%.4f)\n", spend, budget.Spent())
+	if budget.Exceeded() {
+		runWarnings.Warnf("--cost-file has now reached the $%.2f --max-cost budget; subsequent runs sharing it will be skipped", generateMaxCost)
+	}
+
+	if deps := b.DevDependencies(); len(deps) > 0 {
+		logrus.Infof("backend %q requires dev-dependencies %v in the target crate's Cargo.toml", b.Name(), deps)
+	}
+	if detectAsync(redactedContent) {
+		logrus.Infof("%s has async fn(s); the target crate's Cargo.toml needs tokio as a dev-dependency with the \"macros\", \"rt-multi-thread\", and \"test-util\" features for #[tokio::test] to compile", generateFile)
+	}
+
+	for _, r := range recommend.Recommendations(hist, repoRoot) {
+		fmt.Println("recommendation:", r)
+	}
+
+	if streaming {
+		fmt.Println()
+	} else {
+		fmt.Println(generated)
+	}
+
+	emitWebhookEvent("generation-done", generateFile, map[string]any{"model": usedModel, "cost_usd": spend})
+
+	return nil
+}
+
+// runCandidates requests --candidates completions for prompt, validates
+// each concurrently in its own disposable git worktree (with its own
+// CARGO_TARGET_DIR, so the parallel builds never lock each other out of a
+// shared one), and returns the one with the best resulting coverage delta
+// over --coverage-file's baseline (0 if unset), having recorded every
+// candidate to --candidates-file and audited every prompt/response pair to
+// auditLog.
+func runCandidates(provider llm.Provider, limiter *ratelimit.Limiter, prompt string, b backend.Backend, auditLog *audit.Logger) (string, llm.Usage, error) {
+	validateRunner, err := backend.ParseRunner(generateValidateRunner)
+	if err != nil {
+		return "", llm.Usage{}, err
+	}
+
+	baseline := 0.0
+	if generateCoverageFile != "" {
+		stats, err := loadFileStats(generateCoverageFile, generateFile)
+		if err != nil {
+			return "", llm.Usage{}, err
+		}
+		baseline = stats.Percent()
+	}
+
+	toolchainChecks, err := toolchainCheckCommands(resolveCrate(repoRoot, generateFile, generateValidateCrate))
+	if err != nil {
+		return "", llm.Usage{}, err
+	}
+
+	features := resolveFeatures(generateFile)
+	smokeTest := smokeTestCommand(generateFile, generateSmokeTestPaths, generateSmokeTestCommand)
+
+	evaluator := candidate.Evaluator{
+		RepoRoot:               repoRoot,
+		ValidateCommand:        validateCommandOverride(generateValidateCommand, withFeatures(b.ValidateCommand(resolveCrate(repoRoot, generateFile, generateValidateCrate), validateRunner), features)),
+		PreValidateCommand:     splitCommand(generatePreValidateCommand),
+		CheckCommand:           withFeatures(checkCommand(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		SmokeTestCommand:       smokeTest,
+		ClippyCommand:          withFeatures(clippyCommandUnlessSkipped(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		MiriCommand:            withFeatures(miriCommandIfEnabled(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		CoverageCommand:        withFeatures([]string{"cargo", "llvm-cov", "--json"}, features),
+		SkipFormat:             generateSkipRustfmt,
+		MinDelta:               generateMinDelta,
+		MinAssertionDensity:    generateMinAssertionDensity,
+		MutantsCommand:         withFeatures(mutantsCommandIfEnabled(generateFile), features),
+		MinMutationScore:       generateMinMutationScore,
+		CrossCheckCommands:     withFeaturesAll(crossCheckCommands(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		ToolchainCheckCommands: withFeaturesAll(toolchainChecks, features),
+		Denylist:               generateTestDenylist,
+		LogDir:                 generateLogDir,
+		TargetDir:              generateTargetDir,
+		Sccache:                generateSccache,
+		Incremental:            generateIncremental,
+		CommandTimeout:         generateCommandTimeout,
+		BisectFailingTests:     generateBisectFailingTests,
+	}
+
+	var total llm.Usage
+	texts := make([]string, 0, generateCandidates)
+	for i := 0; i < generateCandidates; i++ {
+		if err := limiter.Wait(tokenest.Estimate(prompt)); err != nil {
+			return "", llm.Usage{}, errors.Wrap(err, "wait for rate limit budget")
+		}
+
+		text, usage, err := provider.Complete(generateModel, prompt, generationOptions())
+		if err != nil {
+			return "", llm.Usage{}, errors.Wrap(err, "generate candidate")
+		}
+		total.PromptTokens += usage.PromptTokens
+		total.CompletionTokens += usage.CompletionTokens
+		if err := auditLog.Record(generateFile, generateModel, prompt, text, usage); err != nil {
+			runWarnings.Warnf("failed to record audit log: %v", err)
+		}
+
+		texts = append(texts, text)
+	}
+
+	// Validation, unlike generation above, doesn't share any provider
+	// rate-limit state, so every candidate's worktree checkout, build, and
+	// test run can proceed in parallel instead of one at a time.
+	results := make([]candidate.Result, len(texts))
+	var wg sync.WaitGroup
+	for i, text := range texts {
+		wg.Add(1)
+		go func(i int, text string) {
+			defer wg.Done()
+			results[i] = evaluator.Evaluate(i, generateFile, text, baseline)
+			logrus.Infof("candidate %d: valid=%v coverage=%.2f%% delta=%.2f", i, results[i].Valid, results[i].CoveragePercent, results[i].Delta)
+		}(i, text)
+	}
+	wg.Wait()
+
+	best, ok := candidate.Best(results)
+	selected := -1
+	if ok {
+		selected = best.Index
+	}
+
+	artifacts, err := candidate.OpenArtifacts(generateCandidatesFile)
+	if err != nil {
+		return "", llm.Usage{}, err
+	}
+	if err := artifacts.Record(generateFile, results, selected); err != nil {
+		runWarnings.Warnf("failed to record candidate artifacts: %v", err)
+	}
+
+	if !ok {
+		return "", total, errors.New("no candidate passed validation")
+	}
+	return best.Test, total, nil
+}
+
+// runWithEscalation requests a completion for prompt from --model, validated
+// the same way --candidates validates each of its attempts. If --model's
+// output fails validation maxAttemptsBeforeEscalation times, it retries once
+// more with --escalation-model, so a cheap default model handles the common
+// case while a stronger, costlier one only gets used on the files that
+// actually need it. When --validate-runner=nextest identifies exactly which
+// generated test failed, the next attempt's prompt names it, so the model
+// can fix it instead of blindly regenerating from scratch. Returns the
+// accepted test, its usage, and the model that produced it.
+func runWithEscalation(provider llm.Provider, limiter *ratelimit.Limiter, prompt string, b backend.Backend, auditLog *audit.Logger) (string, llm.Usage, string, error) {
+	validateRunner, err := backend.ParseRunner(generateValidateRunner)
+	if err != nil {
+		return "", llm.Usage{}, generateModel, err
+	}
+
+	baseline := 0.0
+	if generateCoverageFile != "" {
+		stats, err := loadFileStats(generateCoverageFile, generateFile)
+		if err != nil {
+			return "", llm.Usage{}, generateModel, err
+		}
+		baseline = stats.Percent()
+	}
+
+	toolchainChecks, err := toolchainCheckCommands(resolveCrate(repoRoot, generateFile, generateValidateCrate))
+	if err != nil {
+		return "", llm.Usage{}, generateModel, err
+	}
+
+	features := resolveFeatures(generateFile)
+	smokeTest := smokeTestCommand(generateFile, generateSmokeTestPaths, generateSmokeTestCommand)
+
+	evaluator := candidate.Evaluator{
+		RepoRoot:               repoRoot,
+		ValidateCommand:        validateCommandOverride(generateValidateCommand, withFeatures(b.ValidateCommand(resolveCrate(repoRoot, generateFile, generateValidateCrate), validateRunner), features)),
+		PreValidateCommand:     splitCommand(generatePreValidateCommand),
+		CheckCommand:           withFeatures(checkCommand(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		SmokeTestCommand:       smokeTest,
+		ClippyCommand:          withFeatures(clippyCommandUnlessSkipped(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		MiriCommand:            withFeatures(miriCommandIfEnabled(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		CoverageCommand:        withFeatures([]string{"cargo", "llvm-cov", "--json"}, features),
+		SkipFormat:             generateSkipRustfmt,
+		MinDelta:               generateMinDelta,
+		MinAssertionDensity:    generateMinAssertionDensity,
+		MutantsCommand:         withFeatures(mutantsCommandIfEnabled(generateFile), features),
+		MinMutationScore:       generateMinMutationScore,
+		CrossCheckCommands:     withFeaturesAll(crossCheckCommands(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		ToolchainCheckCommands: withFeaturesAll(toolchainChecks, features),
+		Denylist:               generateTestDenylist,
+		LogDir:                 generateLogDir,
+		TargetDir:              generateTargetDir,
+		Sccache:                generateSccache,
+		Incremental:            generateIncremental,
+		CommandTimeout:         generateCommandTimeout,
+		BisectFailingTests:     generateBisectFailingTests,
+	}
+
+	attempt := func(model, attemptPrompt string, index int) (string, llm.Usage, candidate.Result, error) {
+		if err := limiter.Wait(tokenest.Estimate(attemptPrompt)); err != nil {
+			return "", llm.Usage{}, candidate.Result{}, errors.Wrap(err, "wait for rate limit budget")
+		}
+		text, usage, err := provider.Complete(model, attemptPrompt, generationOptions())
+		if err != nil {
+			return "", llm.Usage{}, candidate.Result{}, errors.Wrap(err, "generate tests")
+		}
+		if err := auditLog.Record(generateFile, model, attemptPrompt, text, usage); err != nil {
+			runWarnings.Warnf("failed to record audit log: %v", err)
+		}
+		return text, usage, evaluator.Evaluate(index, generateFile, text, baseline), nil
+	}
+
+	var total llm.Usage
+	var text string
+	var result candidate.Result
+	var texts []string
+	var results []candidate.Result
+	currentPrompt := prompt
+	start := time.Now()
+	for i := 0; i < maxAttemptsBeforeEscalation; i++ {
+		if deadlineExceeded(start) {
+			logrus.Warnf("--max-duration %s exceeded after %d attempt(s) for %s; stopping retries", generateMaxDuration, i, generateFile)
+			break
+		}
+		var usage llm.Usage
+		var err error
+		text, usage, result, err = attempt(generateModel, currentPrompt, i)
+		if err != nil {
+			return "", total, generateModel, err
+		}
+		total.PromptTokens += usage.PromptTokens
+		total.CompletionTokens += usage.CompletionTokens
+		if result.Valid {
+			return text, total, generateModel, nil
+		}
+		texts = append(texts, text)
+		results = append(results, result)
+		if len(result.FailedTests) > 0 {
+			currentPrompt = prompt + regenerationHint(result.FailedTests)
+		}
+	}
+
+	if generateEscalationModel == "" || deadlineExceeded(start) {
+		if generateEscalationModel != "" {
+			logrus.Warnf("--max-duration %s exceeded; skipping escalation to %s for %s", generateMaxDuration, generateEscalationModel, generateFile)
+		}
+		if best, ok := bestEffortText(texts, results); ok {
+			return best, total, generateModel, nil
+		}
+		if len(results) == 0 {
+			return "", total, generateModel, errors.Errorf("--max-duration %s elapsed before any attempt with %s completed", generateMaxDuration, generateModel)
+		}
+		return "", total, generateModel, errors.Errorf("generated test failed validation after %d attempt(s) with %s: %s", len(results), generateModel, result.Reason)
+	}
+
+	logrus.Infof("escalating %s from %s to %s after %d failed validations", generateFile, generateModel, generateEscalationModel, maxAttemptsBeforeEscalation)
+	text, usage, result, err := attempt(generateEscalationModel, currentPrompt, maxAttemptsBeforeEscalation)
+	if err != nil {
+		return "", total, generateEscalationModel, err
+	}
+	total.PromptTokens += usage.PromptTokens
+	total.CompletionTokens += usage.CompletionTokens
+	if !result.Valid {
+		texts = append(texts, text)
+		results = append(results, result)
+		if best, ok := bestEffortText(texts, results); ok {
+			return best, total, generateEscalationModel, nil
+		}
+		return "", total, generateEscalationModel, errors.Errorf("generated test failed validation with escalation model %s: %s", generateEscalationModel, result.Reason)
+	}
+	return text, total, generateEscalationModel, nil
+}
+
+// bestEffortText returns candidate.StripFailingTests applied to the
+// candidate.BestEffort attempt among texts/results, or ok=false if
+// --apply-best-effort wasn't given or no attempt was ever generated, so
+// runWithEscalation can offer a partially working result instead of
+// discarding every attempt once retries and escalation are both exhausted.
+func bestEffortText(texts []string, results []candidate.Result) (string, bool) {
+	if !generateApplyBestEffort || len(texts) == 0 {
+		return "", false
+	}
+
+	best, ok := candidate.BestEffort(results)
+	if !ok {
+		return "", false
+	}
+
+	logrus.Warnf("applying best-effort attempt %d for %s with its failing test(s) stripped: %s", best.Index, generateFile, best.Reason)
+	return candidate.StripFailingTests(texts[best.Index], best.FailedTests), true
+}
+
+// deadlineExceeded reports whether --max-duration has elapsed since start;
+// always false when --max-duration is unset (0).
+func deadlineExceeded(start time.Time) bool {
+	return generateMaxDuration > 0 && time.Since(start) >= generateMaxDuration
+}
+
+// regenerationHint tells the model which of its previously generated tests
+// failed validation, so a retry can fix them instead of regenerating blind.
+func regenerationHint(failedTests []string) string {
+	return fmt.Sprintf(
+		"\n\nThe previous attempt's generated tests failed: %s. Fix them; do not reintroduce the same failures.",
+		strings.Join(failedTests, ", "),
+	)
+}
+
+// runChunkedGeneration splits content's functions into groups of
+// --chunk-group-size and requests one completion per group instead of a
+// single prompt for the whole file, so a file over --chunk-threshold-lines
+// doesn't risk a truncated completion. The resulting #[cfg(test)]
+// snippets are concatenated in the functions' original order.
+func runChunkedGeneration(provider llm.Provider, limiter *ratelimit.Limiter, content, moduleContext string, auditLog *audit.Logger) (string, llm.Usage, error) {
+	functions := chunk.Split(content)
+	if len(functions) == 0 {
+		return "", llm.Usage{}, errors.Errorf("%s exceeds --chunk-threshold-lines but no functions were found to chunk", generateFile)
+	}
+
+	groups := chunk.Group(functions, generateChunkGroupSize)
+	var total llm.Usage
+	parts := make([]string, 0, len(groups))
+	for i, group := range groups {
+		var groupSource strings.Builder
+		for _, fn := range group {
+			groupSource.WriteString(fn.Body)
+			groupSource.WriteString("\n\n")
+		}
+
+		prompt := fmt.Sprintf(
+			"You are generating Rust unit tests for the following file, %s.\n\nModule context:\n%s\n\nFull file, for reference:\n%s\n\n"+
+				"For this request, only write tests for these specific functions from the file:\n%s\n\n"+
+				"Write a #[cfg(test)] module with tests for just those functions; separate requests are covering the rest of the file.",
+			generateFile, moduleContext, content, groupSource.String(),
+		)
+
+		if err := limiter.Wait(tokenest.Estimate(prompt)); err != nil {
+			return "", llm.Usage{}, errors.Wrap(err, "wait for rate limit budget")
+		}
+
+		text, usage, err := provider.Complete(generateModel, prompt, generationOptions())
+		if err != nil {
+			return "", llm.Usage{}, errors.Wrapf(err, "generate chunk %d/%d", i+1, len(groups))
+		}
+		total.PromptTokens += usage.PromptTokens
+		total.CompletionTokens += usage.CompletionTokens
+		if err := auditLog.Record(generateFile, generateModel, prompt, text, usage); err != nil {
+			runWarnings.Warnf("failed to record audit log: %v", err)
+		}
+
+		parts = append(parts, text)
+	}
+
+	return strings.Join(parts, "\n\n"), total, nil
+}
+
+// runGenerateFromCommit generates a regression test for the bug fixed by
+// generateFromCommit: it feeds the fix's diff and the target file to the
+// model, then proves the resulting test fails against the parent commit
+// and passes against the fix itself before printing it.
+func runGenerateFromCommit(provider llm.Provider, auditLog *audit.Logger) error {
+	verifier := regression.Verifier{RepoRoot: repoRoot}
+
+	diff, err := verifier.Diff(generateFromCommit)
+	if err != nil {
+		return err
+	}
+	diff = redactSecrets(diff)
+
+	content, err := os.ReadFile(generateFile)
+	if err != nil {
+		return errors.Wrap(err, "read target file")
+	}
+
+	prompt := fmt.Sprintf(
+		"The following commit fixed a bug:\n\n%s\n\nHere is the fixed file, %s:\n\n%s\n\n"+
+			"Write a #[cfg(test)] regression test that fails against the code before this fix "+
+			"and passes against the code after it.",
+		diff, generateFile, redactSecrets(string(content)),
+	)
+
+	generated, usage, err := provider.Complete(generateModel, prompt, generationOptions())
+	if err != nil {
+		return errors.Wrap(err, "generate regression test")
+	}
+	if err := auditLog.Record(generateFile, generateModel, prompt, generated, usage); err != nil {
+		runWarnings.Warnf("failed to record audit log: %v", err)
+	}
+
+	ok, err := verifier.VerifyFix(generateFromCommit, generateFile, generated)
+	if err != nil {
+		return errors.Wrap(err, "verify regression test")
+	}
+	if !ok {
+		return errors.Errorf("generated regression test did not fail before %s and pass after it", generateFromCommit)
+	}
+
+	fmt.Println(generated)
+	return nil
+}
+
+// runGenerateFromIssue generates a test reproducing the bug described by
+// generateFromIssue, a GitHub issue URL, against the target file.
+func runGenerateFromIssue(provider llm.Provider, auditLog *audit.Logger) error {
+	iss, err := issue.Fetch(generateFromIssue)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(generateFile)
+	if err != nil {
+		return errors.Wrap(err, "read target file")
+	}
+
+	prompt := fmt.Sprintf(
+		"The following GitHub issue describes a bug or behavior:\n\nTitle: %s\n\n%s\n\n"+
+			"Here is the relevant file, %s:\n\n%s\n\n"+
+			"Write a #[cfg(test)] test that reproduces or characterizes the behavior described in the issue.",
+		redactSecrets(iss.Title), redactSecrets(iss.Body), generateFile, redactSecrets(string(content)),
+	)
+
+	generated, usage, err := provider.Complete(generateModel, prompt, generationOptions())
+	if err != nil {
+		return errors.Wrap(err, "generate issue-reproducing test")
+	}
+	if err := auditLog.Record(generateFile, generateModel, prompt, generated, usage); err != nil {
+		runWarnings.Warnf("failed to record audit log: %v", err)
+	}
+
+	fmt.Println(generated)
+	return nil
+}
+
+// runGenerateFromFile skips the llm provider entirely and validates a
+// human-written or externally generated test file at generateFromFile
+// through the same integrate/validate/report pipeline --candidates uses,
+// so a run works in air-gapped environments that can't reach a provider.
+func runGenerateFromFile(auditLog *audit.Logger) error {
+	b, err := backend.Get(generateBackend)
+	if err != nil {
+		return err
+	}
+
+	validateRunner, err := backend.ParseRunner(generateValidateRunner)
+	if err != nil {
+		return err
+	}
+
+	generated, err := os.ReadFile(generateFromFile)
+	if err != nil {
+		return errors.Wrap(err, "read --from-file")
+	}
+
+	baseline := 0.0
+	if generateCoverageFile != "" {
+		stats, err := loadFileStats(generateCoverageFile, generateFile)
+		if err != nil {
+			return err
+		}
+		baseline = stats.Percent()
+	}
+
+	toolchainChecks, err := toolchainCheckCommands(resolveCrate(repoRoot, generateFile, generateValidateCrate))
+	if err != nil {
+		return err
+	}
+
+	features := resolveFeatures(generateFile)
+	smokeTest := smokeTestCommand(generateFile, generateSmokeTestPaths, generateSmokeTestCommand)
+
+	evaluator := candidate.Evaluator{
+		RepoRoot:               repoRoot,
+		ValidateCommand:        validateCommandOverride(generateValidateCommand, withFeatures(b.ValidateCommand(resolveCrate(repoRoot, generateFile, generateValidateCrate), validateRunner), features)),
+		PreValidateCommand:     splitCommand(generatePreValidateCommand),
+		CheckCommand:           withFeatures(checkCommand(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		SmokeTestCommand:       smokeTest,
+		ClippyCommand:          withFeatures(clippyCommandUnlessSkipped(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		MiriCommand:            withFeatures(miriCommandIfEnabled(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		CoverageCommand:        withFeatures([]string{"cargo", "llvm-cov", "--json"}, features),
+		SkipFormat:             generateSkipRustfmt,
+		MinDelta:               generateMinDelta,
+		MinAssertionDensity:    generateMinAssertionDensity,
+		MutantsCommand:         withFeatures(mutantsCommandIfEnabled(generateFile), features),
+		MinMutationScore:       generateMinMutationScore,
+		CrossCheckCommands:     withFeaturesAll(crossCheckCommands(resolveCrate(repoRoot, generateFile, generateValidateCrate)), features),
+		ToolchainCheckCommands: withFeaturesAll(toolchainChecks, features),
+		Denylist:               generateTestDenylist,
+		LogDir:                 generateLogDir,
+		TargetDir:              generateTargetDir,
+		Sccache:                generateSccache,
+		Incremental:            generateIncremental,
+		CommandTimeout:         generateCommandTimeout,
+		BisectFailingTests:     generateBisectFailingTests,
+	}
+	result := evaluator.Evaluate(0, generateFile, string(generated), baseline)
+	logrus.Infof("from-file candidate: valid=%v coverage=%.2f%% delta=%.2f", result.Valid, result.CoveragePercent, result.Delta)
+
+	selected := -1
+	if result.Valid {
+		selected = 0
+	}
+	artifacts, err := candidate.OpenArtifacts(generateCandidatesFile)
+	if err != nil {
+		return err
+	}
+	if err := artifacts.Record(generateFile, []candidate.Result{result}, selected); err != nil {
+		runWarnings.Warnf("failed to record candidate artifacts: %v", err)
+	}
+
+	if err := auditLog.Record(generateFile, "offline:from-file", generateFromFile, string(generated), llm.Usage{}); err != nil {
+		runWarnings.Warnf("failed to record audit log: %v", err)
+	}
+
+	if !result.Valid {
+		return errors.Errorf("--from-file test did not pass validation: %s", result.Reason)
+	}
+
+	fmt.Println(string(generated))
+	return nil
+}
+
+// collectModuleFiles dumps the contents of every sibling file in the target
+// file's directory, so the model has the surrounding module as context.
+func collectModuleFiles(target string) string {
+	return strings.Join(collectModuleFileBlocks(target), "")
+}
+
+// moduleFile is one sibling file collected as module context, before it is
+// rendered into a prompt block.
+type moduleFile struct {
+	Name    string
+	Content string
+}
+
+// collectModuleFileEntries returns every sibling .rs file in the target
+// file's directory, so callers can rank or filter them before rendering.
+func collectModuleFileEntries(target string) []moduleFile {
+	dir := filepath.Dir(target)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		runWarnings.Warnf("failed to read module directory %s: %v", dir, err)
+		return nil
+	}
+
+	var files []moduleFile
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Join(dir, entry.Name()) == target {
+			continue
+		}
+		if filepath.Ext(entry.Name()) != ".rs" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		files = append(files, moduleFile{Name: entry.Name(), Content: string(data)})
+	}
+
+	return files
+}
+
+// collectModuleFileBlocks returns one rendered "// --- name ---\n<contents>"
+// block per sibling file in the target file's directory, so callers that
+// need to trim context can drop individual blocks instead of the whole
+// module at once.
+func collectModuleFileBlocks(target string) []string {
+	entries := collectModuleFileEntries(target)
+	blocks := make([]string, len(entries))
+	for i, e := range entries {
+		blocks[i] = formatBlock(e.Name, e.Content)
+	}
+	return blocks
+}
+
+// rankModuleContext orders entries by relevance to targetContent using
+// --embeddings-provider, and truncates to the top --module-context-top-k,
+// so a large sibling directory doesn't get dumped into the prompt in full.
+func rankModuleContext(entries []moduleFile, targetContent string) ([]moduleFile, error) {
+	embedder, err := resolveEmbedder()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]embed.Candidate, len(entries))
+	byName := make(map[string]moduleFile, len(entries))
+	for i, e := range entries {
+		candidates[i] = embed.Candidate{Path: e.Name, Content: e.Content}
+		byName[e.Name] = e
+	}
+
+	ranked, err := embed.Rank(embedder, targetContent, candidates)
+	if err != nil {
+		return nil, errors.Wrap(err, "rank module context by relevance")
+	}
+	if len(ranked) > generateModuleContextTopK {
+		ranked = ranked[:generateModuleContextTopK]
+	}
+
+	top := make([]moduleFile, len(ranked))
+	for i, name := range ranked {
+		top[i] = byName[name]
+	}
+	return top, nil
+}
+
+// resolveEmbedder returns the embed.Embedder selected by
+// --embeddings-provider.
+func resolveEmbedder() (embed.Embedder, error) {
+	switch generateEmbeddingsProvider {
+	case "", "local":
+		return embed.Local{}, nil
+	case "api":
+		if generateAPIBase == "" {
+			return nil, errors.New("--embeddings-provider=api requires --api-base")
+		}
+		return llm.OpenAIEmbedder{BaseURL: generateAPIBase, APIKeyEnv: generateAPIKeyEnv, Model: generateEmbeddingsModel, Timeout: generateAPITimeout, ProxyURL: generateProxy}, nil
+	default:
+		return nil, errors.Errorf("unknown embeddings provider %q", generateEmbeddingsProvider)
+	}
+}
+
+// formatBlock renders one "// --- name ---\n<contents>" prompt block.
+func formatBlock(name, content string) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// --- %s ---\n%s\n", name, content)
+	return buf.String()
+}
+
+// formatExemplarBlocks renders one "// --- path ---\n<contents>" block per
+// exemplar, matching collectModuleFileBlocks' format so both kinds of
+// context read the same way in a prompt.
+func formatExemplarBlocks(examples []exemplar.Example) []string {
+	blocks := make([]string, 0, len(examples))
+	for _, e := range examples {
+		blocks = append(blocks, formatBlock(e.Path, e.Content))
+	}
+	return blocks
+}
+
+// trimModuleContextToFit calls build with successively smaller sets of
+// module-context blocks — dropping the largest first, since they cost the
+// most tokens for the least specific signal — until the assembled prompt
+// fits contextWindow tokens (after reserving reserveTokens for the
+// completion), so a run trims context instead of sending a request the
+// model rejects outright. A zero contextWindow disables the check.
+func trimModuleContextToFit(build func(moduleContext string) string, blocks []string, contextWindow, reserveTokens int) string {
+	remaining := append([]string(nil), blocks...)
+	for {
+		prompt := build(strings.Join(remaining, ""))
+		if contextWindow == 0 || tokenest.Fits(tokenest.Estimate(prompt), contextWindow, reserveTokens) || len(remaining) == 0 {
+			return prompt
+		}
+
+		drop := largestBlock(remaining)
+		runWarnings.Warnf("dropping a module context block to fit the %d-token context window", contextWindow)
+		remaining = append(remaining[:drop], remaining[drop+1:]...)
+	}
+}
+
+// largestBlock returns the index of the longest string in blocks.
+func largestBlock(blocks []string) int {
+	largest := 0
+	for i, b := range blocks {
+		if len(b) > len(blocks[largest]) {
+			largest = i
+		}
+	}
+	return largest
+}
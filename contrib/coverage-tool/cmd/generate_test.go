@@ -0,0 +1,80 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/backend"
+)
+
+func TestTrimModuleContextToFitDropsLargestBlockFirst(t *testing.T) {
+	blocks := []string{"// --- small.rs ---\nshort\n", "// --- big.rs ---\n" + strings.Repeat("x", 500) + "\n"}
+	build := func(moduleContext string) string {
+		return backend.Unit{}.BuildPrompt("target file", moduleContext, backend.PromptOptions{})
+	}
+
+	prompt := trimModuleContextToFit(build, blocks, 100, 0)
+
+	if strings.Contains(prompt, "big.rs") {
+		t.Error("trimModuleContextToFit should have dropped the largest block first")
+	}
+	if !strings.Contains(prompt, "small.rs") {
+		t.Error("trimModuleContextToFit should keep blocks once the prompt fits")
+	}
+}
+
+func TestTrimModuleContextToFitKeepsEverythingWhenDisabled(t *testing.T) {
+	blocks := []string{"// --- big.rs ---\n" + strings.Repeat("x", 5000) + "\n"}
+	build := func(moduleContext string) string {
+		return backend.Unit{}.BuildPrompt("target file", moduleContext, backend.PromptOptions{})
+	}
+
+	prompt := trimModuleContextToFit(build, blocks, 0, 0)
+
+	if !strings.Contains(prompt, "big.rs") {
+		t.Error("trimModuleContextToFit should not trim when contextWindow is 0")
+	}
+}
+
+func TestLargestBlockReturnsLongestIndex(t *testing.T) {
+	blocks := []string{"a", "bbb", "cc"}
+	if got := largestBlock(blocks); got != 1 {
+		t.Errorf("largestBlock() = %d, want 1", got)
+	}
+}
+
+func TestServeFileToolReadsAllowlistedFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.rs"), []byte("fn a() {}"), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	handler := serveFileTool(root)
+	got, err := handler("read_file", json.RawMessage(`{"path":"a.rs"}`))
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if got != "fn a() {}" {
+		t.Errorf("handler(a.rs) = %q, want file contents", got)
+	}
+}
+
+func TestServeFileToolRejectsPathEscapingRepoRoot(t *testing.T) {
+	root := t.TempDir()
+
+	handler := serveFileTool(root)
+	got, err := handler("read_file", json.RawMessage(`{"path":"../secret"}`))
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if !strings.Contains(got, "outside the repository") {
+		t.Errorf("handler(../secret) = %q, want an outside-the-repository error", got)
+	}
+}
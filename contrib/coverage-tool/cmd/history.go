@@ -0,0 +1,60 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/historydb"
+)
+
+var historyDBFile string
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Print coverage-tool's run history from its SQLite database",
+	Long: `history prints every run recorded in the SQLite database populated by
+report --history-db: coverage before/after, whether the change was
+accepted, which model generated it, and its cost, for a weekly coverage
+review.`,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyDBFile, "db", ".coverage-tool/history.db", "path to the SQLite run history database")
+}
+
+func runHistory(_ *cobra.Command, _ []string) error {
+	db, err := historydb.Open(historyDBFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	runs, err := db.Runs()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-20s %-40s %-16s %7s %7s %8s %10s\n", "TIMESTAMP", "FILE", "MODEL", "BEFORE", "AFTER", "ACCEPTED", "COST")
+	var accepted int
+	var totalCost float64
+	models := make(map[string]struct{})
+	for _, r := range runs {
+		fmt.Printf("%-20s %-40s %-16s %6.1f%% %6.1f%% %8t %9.4f\n",
+			r.Timestamp.Format("2006-01-02 15:04"), r.File, r.Model, r.BeforePercent, r.AfterPercent, r.Accepted, r.CostUSD)
+		if r.Accepted {
+			accepted++
+		}
+		totalCost += r.CostUSD
+		models[r.Model] = struct{}{}
+	}
+
+	fmt.Printf("\n%d run(s), %d accepted, %d model(s) used, $%.2f total\n", len(runs), accepted, len(models), totalCost)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/churn"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/selector"
+)
+
+var imageEntrypointCmd = &cobra.Command{
+	Use:   "image-entrypoint",
+	Short: "Run analysis with all settings read from the environment",
+	Long: `image-entrypoint runs the same selection analyze does, but takes every
+setting from COVERAGE_TOOL_* environment variables instead of flags, and
+writes its result as JSON under COVERAGE_TOOL_OUTPUT_DIR. It never opens
+the source tree for writing, so it can run as a distroless image with a
+read-only root filesystem in a Kubernetes CronJob, with only the output
+volume mounted read-write.`,
+	RunE: runImageEntrypoint,
+}
+
+func runImageEntrypoint(_ *cobra.Command, _ []string) error {
+	repo := envOr("COVERAGE_TOOL_REPO", ".")
+	coverageFile := envOr("COVERAGE_TOOL_COVERAGE_FILE", "coverage.json")
+	strategy := envOr("COVERAGE_TOOL_STRATEGY", "coverage")
+	outputDir := envOr("COVERAGE_TOOL_OUTPUT_DIR", "/output")
+	limit, err := strconv.Atoi(envOr("COVERAGE_TOOL_LIMIT", "0"))
+	if err != nil {
+		return errors.Wrap(err, "parse COVERAGE_TOOL_LIMIT")
+	}
+	churnWindow, err := time.ParseDuration(envOr("COVERAGE_TOOL_CHURN_WINDOW", "2160h"))
+	if err != nil {
+		return errors.Wrap(err, "parse COVERAGE_TOOL_CHURN_WINDOW")
+	}
+
+	files, err := loadReport(coverageFile)
+	if err != nil {
+		return err
+	}
+	files = selector.ExcludeGenerated(repo, files)
+
+	// The history store lives under the writable output volume: the
+	// source tree mounted at repo is read-only in this mode.
+	hist, err := history.Open(filepath.Join(outputDir, "history.json"))
+	if err != nil {
+		return err
+	}
+
+	opts := selector.Options{History: hist}
+	if strategy == "churn" {
+		if opts.CommitCounts, err = churn.CountsSince(repo, churnWindow); err != nil {
+			return err
+		}
+	}
+
+	candidates, err := selector.Apply(strategy, files, limit, opts)
+	if err != nil {
+		return err
+	}
+
+	return writeCandidates(filepath.Join(outputDir, "candidates.json"), candidates)
+}
+
+func envOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func writeCandidates(path string, candidates any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create output file")
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(candidates)
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvOrFallsBackWhenUnset(t *testing.T) {
+	if got := envOr("COVERAGE_TOOL_TEST_UNSET_VAR", "fallback"); got != "fallback" {
+		t.Errorf("envOr = %q, want %q", got, "fallback")
+	}
+
+	t.Setenv("COVERAGE_TOOL_TEST_SET_VAR", "set")
+	if got := envOr("COVERAGE_TOOL_TEST_SET_VAR", "fallback"); got != "set" {
+		t.Errorf("envOr = %q, want %q", got, "set")
+	}
+}
+
+func TestWriteCandidatesWritesJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "candidates.json")
+	if err := writeCandidates(path, []string{"a.rs", "b.rs"}); err != nil {
+		t.Fatalf("writeCandidates returned error: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+
+	var got []string
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "a.rs" || got[1] != "b.rs" {
+		t.Errorf("writeCandidates wrote %v, want [a.rs b.rs]", got)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/junit"
+)
+
+var (
+	junitFile          string
+	junitBeforeFile    string
+	junitAfterFile     string
+	junitHistoryFile   string
+	junitAnalyzeError  string
+	junitGenerateError string
+	junitOutput        string
+)
+
+var junitCmd = &cobra.Command{
+	Use:   "junit-report",
+	Short: "Render a coverage-improvement run's stages as a JUnit XML report",
+	Long: `junit-report summarizes a run's analyze, generate, validate attempts, and
+coverage delta stages as JUnit XML, so CI systems like Jenkins that
+already parse JUnit results render the run as structured test results
+without a dedicated plugin. Pass --analyze-error/--generate-error when
+those stages failed, since coverage-tool's separate CLI invocations have
+no other way to observe each other's outcome.`,
+	RunE: runJUnit,
+}
+
+func init() {
+	junitCmd.Flags().StringVar(&junitFile, "file", "", "target source file the run covers")
+	junitCmd.Flags().StringVar(&junitBeforeFile, "before", "", "coverage export from before generation")
+	junitCmd.Flags().StringVar(&junitAfterFile, "after", "", "coverage export from after generation")
+	junitCmd.Flags().StringVar(&junitHistoryFile, "history-file", ".coverage-tool/history.json", "path to the generation history store to pull file's validate attempts from")
+	junitCmd.Flags().StringVar(&junitAnalyzeError, "analyze-error", "", "error message from the analyze stage, if it failed (empty means it passed)")
+	junitCmd.Flags().StringVar(&junitGenerateError, "generate-error", "", "error message from the generate stage, if it failed (empty means it passed)")
+	junitCmd.Flags().StringVar(&junitOutput, "output", "junit.xml", "path to write the JUnit XML report to")
+}
+
+func runJUnit(_ *cobra.Command, _ []string) error {
+	before, err := loadFileStats(junitBeforeFile, junitFile)
+	if err != nil {
+		return err
+	}
+	after, err := loadFileStats(junitAfterFile, junitFile)
+	if err != nil {
+		return err
+	}
+
+	hist, err := history.Open(junitHistoryFile)
+	if err != nil {
+		return err
+	}
+
+	cases := []junit.TestCase{
+		{Name: "analyze", Failure: junitAnalyzeError},
+		{Name: "generate", Failure: junitGenerateError},
+	}
+
+	for i, attempt := range hist.AttemptsFor(junitFile) {
+		failure := ""
+		if !attempt.Success {
+			failure = attempt.Reason
+		}
+		cases = append(cases, junit.TestCase{Name: fmt.Sprintf("validate#%d", i+1), Failure: failure})
+	}
+
+	delta := after.Percent() - before.Percent()
+	deltaCase := junit.TestCase{Name: "coverage-delta"}
+	if delta < 0 {
+		deltaCase.Failure = fmt.Sprintf("coverage dropped from %.2f%% to %.2f%% (%+.2f%%)", before.Percent(), after.Percent(), delta)
+	}
+	cases = append(cases, deltaCase)
+
+	if err := junit.Write(junitOutput, []junit.Suite{{Name: junitFile, Cases: cases}}); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %s\n", junitOutput)
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+)
+
+// resolveProvider returns the OpenAI-compatible provider at apiBase when
+// set, so any vLLM/LiteLLM-style gateway can be used without code changes,
+// falling back to the named built-in provider (github-models, ollama, llama.cpp)
+// otherwise. timeout and proxyURL configure the provider's HTTP client. The
+// result retries on a 429/503 with jittered exponential backoff, honoring
+// the server's Retry-After header when present.
+func resolveProvider(providerName, apiBase, apiKeyEnv string, maxAttempts int, baseDelay, timeout time.Duration, proxyURL string) (llm.Provider, error) {
+	var provider llm.Provider
+	var err error
+	if apiBase != "" {
+		provider = llm.OpenAICompatible{BaseURL: apiBase, APIKeyEnv: apiKeyEnv, Timeout: timeout, ProxyURL: proxyURL}
+	} else {
+		provider, err = llm.Get(providerName, timeout, proxyURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return llm.WithRetry(provider, llm.RetryOptions{MaxAttempts: maxAttempts, BaseDelay: baseDelay}), nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveProviderPrefersAPIBase(t *testing.T) {
+	provider, err := resolveProvider("github-models", "http://localhost:8000/v1", "", 3, time.Second, 0, "")
+	if err != nil {
+		t.Fatalf("resolveProvider returned error: %v", err)
+	}
+	if provider.Name() != "openai-compatible" {
+		t.Errorf("resolveProvider Name() = %q, want openai-compatible when --api-base is set", provider.Name())
+	}
+}
+
+func TestResolveProviderFallsBackToNamedProvider(t *testing.T) {
+	provider, err := resolveProvider("ollama", "", "", 3, time.Second, 0, "")
+	if err != nil {
+		t.Fatalf("resolveProvider returned error: %v", err)
+	}
+	if provider.Name() != "ollama" {
+		t.Errorf("resolveProvider Name() = %q, want ollama", provider.Name())
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/notify"
+)
+
+var (
+	notifyFile        string
+	notifyBeforeFile  string
+	notifyAfterFile   string
+	notifyPRURL       string
+	notifySuccess     bool
+	notifyWebhookURL  string
+	notifyMinSeverity string
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Post a compact Slack message when a coverage-improvement run finishes or fails",
+	Long: `notify posts a single-line Slack message with the target file,
+before/after coverage, PR link, and status, so a team can watch runs
+without polling CI. --slack-webhook-url falls back to the SLACK_WEBHOOK_URL
+environment variable, since it is a secret. --min-severity drops
+notifications below it (info for an improved run, warning for a
+successful run that regressed coverage, error for a failed run).`,
+	RunE: runNotify,
+}
+
+func init() {
+	notifyCmd.Flags().StringVar(&notifyFile, "file", "", "target source file the run covers")
+	notifyCmd.Flags().StringVar(&notifyBeforeFile, "before", "", "coverage export from before generation")
+	notifyCmd.Flags().StringVar(&notifyAfterFile, "after", "", "coverage export from after generation")
+	notifyCmd.Flags().StringVar(&notifyPRURL, "pr-url", "", "URL of the pull request the run produced, if any")
+	notifyCmd.Flags().BoolVar(&notifySuccess, "success", true, "whether the run succeeded")
+	notifyCmd.Flags().StringVar(&notifyWebhookURL, "slack-webhook-url", "", "Slack incoming webhook URL (falls back to the SLACK_WEBHOOK_URL environment variable)")
+	notifyCmd.Flags().StringVar(&notifyMinSeverity, "min-severity", "info", "minimum severity to notify at (info, warning, error)")
+}
+
+func runNotify(_ *cobra.Command, _ []string) error {
+	webhookURL := notifyWebhookURL
+	if webhookURL == "" {
+		webhookURL = os.Getenv("SLACK_WEBHOOK_URL")
+	}
+	if webhookURL == "" {
+		return errors.New("no Slack webhook URL: pass --slack-webhook-url or set SLACK_WEBHOOK_URL")
+	}
+
+	minSeverity, err := notify.ParseSeverity(notifyMinSeverity)
+	if err != nil {
+		return err
+	}
+
+	before, err := loadFileStats(notifyBeforeFile, notifyFile)
+	if err != nil {
+		return err
+	}
+	after, err := loadFileStats(notifyAfterFile, notifyFile)
+	if err != nil {
+		return err
+	}
+
+	run := notify.Run{
+		File:    notifyFile,
+		Model:   generateModel,
+		Before:  before.Percent(),
+		After:   after.Percent(),
+		PRURL:   notifyPRURL,
+		Success: notifySuccess,
+	}
+
+	if !notify.ShouldNotify(run.Severity(), minSeverity) {
+		fmt.Printf("skipping notification: %s severity is below --min-severity %s\n", run.Severity(), minSeverity)
+		return nil
+	}
+
+	if err := notify.PostSlack(webhookURL, run.Message()); err != nil {
+		return err
+	}
+
+	fmt.Println("posted Slack notification")
+	return nil
+}
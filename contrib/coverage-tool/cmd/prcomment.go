@@ -0,0 +1,121 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/issue"
+)
+
+const prCommentMarker = "<!-- coverage-tool:pr-comment -->"
+
+var (
+	prCommentPR           int
+	prCommentBase         string
+	prCommentCoverageFile string
+	prCommentBaseCoverage string
+	prCommentGithubOwner  string
+	prCommentGithubRepo   string
+)
+
+var prCommentCmd = &cobra.Command{
+	Use:   "pr-comment",
+	Short: "Comment per-file coverage changes on a pull request, without generating any tests",
+	Long: `pr-comment computes how coverage changed for the files touched by a
+pull request and posts the result as a single, self-updating PR comment.
+Unlike analyze/generate, it does not call a language model, so it is
+useful to every contributor, not just those relying on AI-generated
+tests.`,
+	RunE: runPRComment,
+}
+
+func init() {
+	prCommentCmd.Flags().IntVar(&prCommentPR, "pr", 0, "pull request number to comment on")
+	prCommentCmd.Flags().StringVar(&prCommentBase, "base", "origin/main", "base git ref the PR is diffed against")
+	prCommentCmd.Flags().StringVar(&prCommentCoverageFile, "coverage-file", "coverage.json", "path to the PR head's cargo llvm-cov JSON export")
+	prCommentCmd.Flags().StringVar(&prCommentBaseCoverage, "base-coverage-file", "base-coverage.json", "path to the base ref's cargo llvm-cov JSON export")
+	prCommentCmd.Flags().StringVar(&prCommentGithubOwner, "github-owner", "bergwolf", "GitHub organization or user owning the repository")
+	prCommentCmd.Flags().StringVar(&prCommentGithubRepo, "github-repo", "nydus", "GitHub repository name")
+	_ = prCommentCmd.MarkFlagRequired("pr")
+}
+
+func runPRComment(_ *cobra.Command, _ []string) error {
+	changed, err := changedFiles(prCommentBase)
+	if err != nil {
+		return err
+	}
+
+	head, err := loadReport(prCommentCoverageFile)
+	if err != nil {
+		return err
+	}
+	base, err := loadReport(prCommentBaseCoverage)
+	if err != nil {
+		return err
+	}
+
+	body := buildPRComment(changed, base, head)
+
+	return issue.UpsertComment(prCommentGithubOwner, prCommentGithubRepo, prCommentPR, body, prCommentMarker)
+}
+
+// changedFiles returns the paths touched between base and HEAD.
+func changedFiles(base string) ([]string, error) {
+	out, err := exec.Command("git", "-C", repoRoot, "diff", "--name-only", base+"...HEAD").Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "git diff")
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+func buildPRComment(changed []string, base, head []coverage.FileStats) string {
+	baseByPath := make(map[string]coverage.FileStats, len(base))
+	for _, f := range base {
+		baseByPath[f.Path] = f
+	}
+	headByPath := make(map[string]coverage.FileStats, len(head))
+	for _, f := range head {
+		headByPath[f.Path] = f
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, prCommentMarker)
+	fmt.Fprintln(&buf, "## Coverage diff")
+	fmt.Fprintln(&buf, "\n| File | Base | Head | Delta |")
+	fmt.Fprintln(&buf, "| --- | --- | --- | --- |")
+
+	touched := 0
+	for _, path := range changed {
+		h, ok := headByPath[path]
+		if !ok {
+			continue
+		}
+		b := baseByPath[path]
+		delta := h.Percent() - b.Percent()
+		fmt.Fprintf(&buf, "| %s | %.1f%% | %.1f%% | %+.1f%% |\n", path, b.Percent(), h.Percent(), delta)
+		touched++
+	}
+
+	if touched == 0 {
+		fmt.Fprintln(&buf, "| _no covered files changed_ | | | |")
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+func TestBuildPRCommentReportsDelta(t *testing.T) {
+	base := []coverage.FileStats{{Path: "storage/src/device.rs", LinesCovered: 40, LinesTotal: 100}}
+	head := []coverage.FileStats{{Path: "storage/src/device.rs", LinesCovered: 80, LinesTotal: 100}}
+
+	body := buildPRComment([]string{"storage/src/device.rs"}, base, head)
+
+	if !strings.Contains(body, prCommentMarker) {
+		t.Error("comment should contain the pinning marker")
+	}
+	if !strings.Contains(body, "storage/src/device.rs") {
+		t.Error("comment should mention the changed file")
+	}
+	if !strings.Contains(body, "+40.0%") {
+		t.Errorf("comment should report the coverage delta, got:\n%s", body)
+	}
+}
+
+func TestBuildPRCommentNoCoveredFilesChanged(t *testing.T) {
+	body := buildPRComment([]string{"docs/README.md"}, nil, nil)
+
+	if !strings.Contains(body, "no covered files changed") {
+		t.Errorf("comment should note that nothing covered changed, got:\n%s", body)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/publish"
+)
+
+var (
+	publishFiles         []string
+	publishGhPagesRemote string
+	publishGhPagesBranch string
+	publishS3Endpoint    string
+	publishS3Region      string
+	publishS3AccessKey   string
+	publishS3SecretKey   string
+	publishS3Prefix      string
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Upload rendered reports, badges, and history charts to a persistent dashboard",
+	Long: `publish copies a set of local files (the HTML report, SVG badges,
+history charts) to a destination that outlives CI's artifact retention
+window, so the workspace has one durable coverage dashboard URL. It
+supports pushing to a gh-pages-style branch and/or an S3-compatible
+bucket (AWS S3, MinIO, or an OSS bucket that speaks the S3 API); set
+either destination's flags, or both to publish to both at once.`,
+	RunE: runPublish,
+}
+
+func init() {
+	publishCmd.Flags().StringArrayVar(&publishFiles, "file", nil, "dest=local file to publish, dest being its path relative to the dashboard root (e.g. badges/coverage.svg=badges/coverage.svg); repeat for more than one")
+	publishCmd.Flags().StringVar(&publishGhPagesRemote, "gh-pages-remote", "", "git remote URL to push the dashboard branch to (empty disables gh-pages publishing)")
+	publishCmd.Flags().StringVar(&publishGhPagesBranch, "gh-pages-branch", "gh-pages", "branch to publish the dashboard to")
+	publishCmd.Flags().StringVar(&publishS3Endpoint, "s3-endpoint", "", "virtual-hosted-style base URL of the destination bucket, e.g. https://my-bucket.s3.us-east-1.amazonaws.com (empty disables S3 publishing)")
+	publishCmd.Flags().StringVar(&publishS3Region, "s3-region", "us-east-1", "region to use in the SigV4 credential scope")
+	publishCmd.Flags().StringVar(&publishS3AccessKey, "s3-access-key-id", "", "S3 access key ID (falls back to the AWS_ACCESS_KEY_ID environment variable)")
+	publishCmd.Flags().StringVar(&publishS3SecretKey, "s3-secret-access-key", "", "S3 secret access key (falls back to the AWS_SECRET_ACCESS_KEY environment variable, since it is a secret)")
+	publishCmd.Flags().StringVar(&publishS3Prefix, "s3-prefix", "", "key prefix to publish objects under within the bucket")
+}
+
+func runPublish(_ *cobra.Command, _ []string) error {
+	files, err := parsePublishFiles(publishFiles)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("nothing to publish: pass --file dest=local at least once")
+	}
+	if publishGhPagesRemote == "" && publishS3Endpoint == "" {
+		return errors.New("no destination: pass --gh-pages-remote and/or --s3-endpoint")
+	}
+
+	if publishGhPagesRemote != "" {
+		if err := publish.GitHubPages(publish.GitHubPagesConfig{
+			RemoteURL: publishGhPagesRemote,
+			Branch:    publishGhPagesBranch,
+		}, files); err != nil {
+			return err
+		}
+		fmt.Printf("published %d file(s) to %s (%s)\n", len(files), publishGhPagesRemote, publishGhPagesBranch)
+	}
+
+	if publishS3Endpoint != "" {
+		accessKey := publishS3AccessKey
+		if accessKey == "" {
+			accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+		}
+		secretKey := publishS3SecretKey
+		if secretKey == "" {
+			secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+
+		cfg := publish.S3Config{
+			Endpoint:        publishS3Endpoint,
+			Region:          publishS3Region,
+			AccessKeyID:     accessKey,
+			SecretAccessKey: secretKey,
+			Prefix:          publishS3Prefix,
+		}
+
+		for dest, local := range files {
+			data, err := os.ReadFile(local)
+			if err != nil {
+				return errors.Wrapf(err, "read %s", local)
+			}
+			if err := cfg.PutObject(dest, data, contentTypeFor(dest)); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("published %d file(s) to %s\n", len(files), publishS3Endpoint)
+	}
+
+	return nil
+}
+
+// parsePublishFiles parses --file's repeated dest=local pairs.
+func parsePublishFiles(pairs []string) (map[string]string, error) {
+	files := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		dest, local, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid --file %q (want dest=local)", pair)
+		}
+		files[dest] = local
+	}
+	return files, nil
+}
+
+// contentTypeFor returns the MIME type to publish path's S3 object with,
+// so a browser renders the dashboard's HTML and SVG badges directly
+// instead of downloading them.
+func contentTypeFor(path string) string {
+	if t := mime.TypeByExtension(filepath.Ext(path)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
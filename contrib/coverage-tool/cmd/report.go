@@ -0,0 +1,434 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/historydb"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/issue"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/mail"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/metrics"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/report"
+)
+
+// reportCommentMarker identifies a file's report comment on a PR, scoped
+// to the target file so reporting on several files against the same PR
+// updates each file's own comment instead of colliding on one.
+func reportCommentMarker(file string) string {
+	return fmt.Sprintf("<!-- coverage-tool:report:%s -->", file)
+}
+
+var (
+	reportFile             string
+	reportBeforeFile       string
+	reportAfterFile        string
+	reportOutputFile       string
+	reportLang             string
+	reportFormat           string
+	reportDiffFile         string
+	reportHistoryFile      string
+	reportPromptTokens     int
+	reportCompletionTokens int
+	reportPR               int
+	reportGithubOwner      string
+	reportGithubRepo       string
+	reportHistoryDB        string
+	reportCostUSD          float64
+	reportAccepted         bool
+	reportGroupByCrate     bool
+	reportSHA              string
+	reportConsolidate      bool
+	reportMetricsOutput    string
+	reportEmailTo          []string
+	reportEmailFrom        string
+	reportEmailSMTPHost    string
+	reportEmailSMTPPort    int
+	reportEmailSMTPUser    string
+	reportEmailSMTPPass    string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Render a coverage improvement report for a target file",
+	RunE:  runReport,
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportFile, "file", "", "target source file the report covers")
+	reportCmd.Flags().StringVar(&reportBeforeFile, "before", "", "coverage export from before generation")
+	reportCmd.Flags().StringVar(&reportAfterFile, "after", "", "coverage export from after generation")
+	reportCmd.Flags().StringVar(&reportOutputFile, "output", "coverage_report.md", "path to write the markdown report to")
+	reportCmd.Flags().StringVar(&reportLang, "report-lang", "en", "language to render the report's section templates in (en, zh)")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "markdown", "report format to render (markdown, html, json)")
+	reportCmd.Flags().StringVar(&reportDiffFile, "diff-file", "", "path to a unified diff of the inserted test to embed in the report (html only)")
+	reportCmd.Flags().StringVar(&reportHistoryFile, "history-file", ".coverage-tool/history.json", "path to the generation history store to pull file's attempts from, rendered as an expenses section (json: full attempt list; markdown/html: per-attempt table)")
+	reportCmd.Flags().IntVar(&reportPromptTokens, "prompt-tokens", 0, "prompt tokens spent generating file's accepted candidate (json only)")
+	reportCmd.Flags().IntVar(&reportCompletionTokens, "completion-tokens", 0, "completion tokens spent generating file's accepted candidate (json only)")
+	reportCmd.Flags().IntVar(&reportPR, "pr", 0, "pull request number to post the markdown report as a comment on (0 disables)")
+	reportCmd.Flags().StringVar(&reportGithubOwner, "github-owner", "bergwolf", "GitHub organization or user owning the repository")
+	reportCmd.Flags().StringVar(&reportGithubRepo, "github-repo", "nydus", "GitHub repository name")
+	reportCmd.Flags().StringVar(&reportHistoryDB, "history-db", "", "path to a SQLite database to record this run's outcome to, for the `history` command (empty disables)")
+	reportCmd.Flags().Float64Var(&reportCostUSD, "cost-usd", 0, "estimated dollar cost of generating file's accepted candidate, recorded with --history-db")
+	reportCmd.Flags().BoolVar(&reportAccepted, "accepted", false, "whether file's generated candidate was accepted, recorded with --history-db")
+	reportCmd.Flags().BoolVar(&reportGroupByCrate, "group-by-crate", false, "include a per-crate coverage rollup table alongside file's own before/after")
+	reportCmd.Flags().StringVar(&reportSHA, "sha", "", "commit SHA to link file and its uncovered functions to on GitHub; defaults to the repo's current HEAD, resolved via git")
+	reportCmd.Flags().BoolVar(&reportConsolidate, "consolidate", false, "merge this run's report into the existing one at --output (summary table plus per-file sections) instead of overwriting it; for pipelines that call report once per target file (markdown or json only)")
+	reportCmd.Flags().StringVar(&reportMetricsOutput, "metrics-output", "", "path to write this run's outcome as an OpenMetrics text-format file, for generic CI metric collectors (empty disables)")
+	reportCmd.Flags().StringArrayVar(&reportEmailTo, "email-to", nil, "recipient email address to deliver the rendered report to over SMTP; repeat for more than one (unset disables email delivery)")
+	reportCmd.Flags().StringVar(&reportEmailFrom, "email-from", "", "From address for --email-to (required if --email-to is set)")
+	reportCmd.Flags().StringVar(&reportEmailSMTPHost, "email-smtp-host", "", "SMTP server host to deliver --email-to through (required if --email-to is set)")
+	reportCmd.Flags().IntVar(&reportEmailSMTPPort, "email-smtp-port", 587, "SMTP server port")
+	reportCmd.Flags().StringVar(&reportEmailSMTPUser, "email-smtp-username", "", "SMTP username (empty sends unauthenticated, for relays that only accept mail from trusted networks)")
+	reportCmd.Flags().StringVar(&reportEmailSMTPPass, "email-smtp-password", "", "SMTP password (falls back to the SMTP_PASSWORD environment variable, since it is a secret)")
+}
+
+func runReport(cmd *cobra.Command, _ []string) error {
+	if cfg != nil {
+		applyConfigDefault(cmd, "format", &reportFormat, cfg.ReportFormat)
+	}
+
+	before, err := loadFileStats(reportBeforeFile, reportFile)
+	if err != nil {
+		return err
+	}
+	after, err := loadFileStats(reportAfterFile, reportFile)
+	if err != nil {
+		return err
+	}
+
+	var diff string
+	if reportDiffFile != "" {
+		data, err := os.ReadFile(reportDiffFile)
+		if err != nil {
+			return errors.Wrap(err, "read diff file")
+		}
+		diff = string(data)
+	}
+
+	var attempts []history.Attempt
+	if reportHistoryFile != "" {
+		hist, err := history.Open(reportHistoryFile)
+		if err != nil {
+			return err
+		}
+		attempts = hist.AttemptsFor(reportFile)
+	}
+
+	var crates []report.CrateDelta
+	if reportGroupByCrate {
+		crates, err = crateDeltas(reportBeforeFile, reportAfterFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	sha, err := resolveSHA(repoRoot, reportSHA)
+	if err != nil {
+		runWarnings.Warnf("could not resolve a commit SHA for GitHub permalinks: %v", err)
+	}
+
+	var fileURL string
+	var uncovered []report.UncoveredFunctionRef
+	if sha != "" {
+		fileURL = githubBlobURL(reportGithubOwner, reportGithubRepo, sha, reportFile)
+		uncovered, err = uncoveredFunctionRefs(reportAfterFile, reportFile, reportGithubOwner, reportGithubRepo, sha)
+		if err != nil {
+			return err
+		}
+	}
+
+	result := report.Result{
+		File:             reportFile,
+		FileURL:          fileURL,
+		Before:           before,
+		After:            after,
+		Model:            generateModel,
+		Warnings:         runWarnings.Messages(),
+		Diff:             diff,
+		Attempts:         attempts,
+		PromptTokens:     reportPromptTokens,
+		CompletionTokens: reportCompletionTokens,
+		Lang:             reportLang,
+		Crates:           crates,
+		Uncovered:        uncovered,
+	}
+
+	if reportConsolidate {
+		if err := writeConsolidatedReport(reportOutputFile, reportFormat, result); err != nil {
+			return err
+		}
+	} else {
+		switch reportFormat {
+		case "html":
+			if err := report.WriteHTML(reportOutputFile, result); err != nil {
+				return err
+			}
+		case "json":
+			if err := report.WriteJSON(reportOutputFile, result); err != nil {
+				return err
+			}
+		case "markdown":
+			if err := report.WriteMarkdown(reportOutputFile, result); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("unknown report format %q (want markdown, html, or json)", reportFormat)
+		}
+	}
+
+	fmt.Printf("wrote report to %s\n", reportOutputFile)
+
+	if reportMetricsOutput != "" {
+		if err := metrics.Write(reportMetricsOutput, metrics.Snapshot{
+			File:             reportFile,
+			BeforePercent:    before.Percent(),
+			AfterPercent:     after.Percent(),
+			PromptTokens:     reportPromptTokens,
+			CompletionTokens: reportCompletionTokens,
+			CostUSD:          reportCostUSD,
+			Accepted:         reportAccepted,
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("wrote metrics to %s\n", reportMetricsOutput)
+	}
+
+	if err := writeActionsSummary(result, before, after); err != nil {
+		runWarnings.Warnf("failed to write GitHub Actions summary/outputs: %v", err)
+	}
+
+	if reportPR > 0 {
+		marker := reportCommentMarker(reportFile)
+		body := marker + "\n" + report.Markdown(result)
+		if err := issue.UpsertComment(reportGithubOwner, reportGithubRepo, reportPR, body, marker); err != nil {
+			return err
+		}
+		fmt.Printf("posted report comment on %s/%s#%d\n", reportGithubOwner, reportGithubRepo, reportPR)
+	}
+
+	if len(reportEmailTo) > 0 {
+		if err := emailReport(result); err != nil {
+			return err
+		}
+		fmt.Printf("emailed report to %s\n", strings.Join(reportEmailTo, ", "))
+	}
+
+	if reportHistoryDB != "" {
+		db, err := historydb.Open(reportHistoryDB)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		if err := db.Record(historydb.Run{
+			Timestamp:        time.Now(),
+			File:             reportFile,
+			Model:            generateModel,
+			BeforePercent:    before.Percent(),
+			AfterPercent:     after.Percent(),
+			Accepted:         reportAccepted,
+			PromptTokens:     reportPromptTokens,
+			CompletionTokens: reportCompletionTokens,
+			CostUSD:          reportCostUSD,
+		}); err != nil {
+			return err
+		}
+	}
+
+	emitWebhookEvent("report-ready", reportFile, map[string]any{
+		"before_percent": before.Percent(),
+		"after_percent":  after.Percent(),
+	})
+
+	return nil
+}
+
+// consolidatedStatePath returns where writeConsolidatedReport keeps the raw
+// accumulated results behind outputFile, since markdown and (rendered) json
+// aren't themselves suitable to read back and extend on the next file.
+func consolidatedStatePath(outputFile string) string {
+	return outputFile + ".state.json"
+}
+
+// writeConsolidatedReport merges result into the consolidated report state
+// kept alongside outputFile and re-renders the whole document, so a
+// pipeline that calls report once per target file builds a single combined
+// report instead of each file's report overwriting the last.
+func writeConsolidatedReport(outputFile, format string, result report.Result) error {
+	statePath := consolidatedStatePath(outputFile)
+
+	state, err := report.LoadConsolidatedState(statePath)
+	if err != nil {
+		return err
+	}
+	state.Upsert(result)
+	if err := report.WriteConsolidatedState(statePath, state); err != nil {
+		return err
+	}
+
+	switch format {
+	case "markdown":
+		return report.WriteMarkdownConsolidated(outputFile, state)
+	case "json":
+		return report.WriteJSONConsolidated(outputFile, state)
+	default:
+		return errors.Errorf("--consolidate does not support format %q (want markdown or json)", format)
+	}
+}
+
+// emailReport delivers result to --email-to over SMTP, as HTML if
+// --format is html and plain markdown otherwise, for teams that review
+// coverage runs over email instead of GitHub notifications.
+func emailReport(result report.Result) error {
+	password := reportEmailSMTPPass
+	if password == "" {
+		password = os.Getenv("SMTP_PASSWORD")
+	}
+
+	body := report.Markdown(result)
+	html := reportFormat == "html"
+	if html {
+		rendered, err := report.HTML(result)
+		if err != nil {
+			return err
+		}
+		body = rendered
+	}
+
+	return mail.Send(mail.Config{
+		Host:     reportEmailSMTPHost,
+		Port:     reportEmailSMTPPort,
+		Username: reportEmailSMTPUser,
+		Password: password,
+		From:     reportEmailFrom,
+		To:       reportEmailTo,
+	}, fmt.Sprintf("Coverage report: %s", result.File), body, html)
+}
+
+// crateDeltas loads the full before/after coverage exports and rolls each
+// up by Cargo workspace member, so a single file's report can also show
+// how the crate it lives in is doing overall.
+func crateDeltas(beforeFile, afterFile string) ([]report.CrateDelta, error) {
+	before, err := loadReport(beforeFile)
+	if err != nil {
+		return nil, err
+	}
+	after, err := loadReport(afterFile)
+	if err != nil {
+		return nil, err
+	}
+
+	crateOf := func(path string) string { return cratePackageName(repoRoot, path) }
+	beforeByCrate := make(map[string]float64)
+	for _, c := range coverage.RollupByCrate(before, crateOf) {
+		beforeByCrate[c.Crate] = c.Percent()
+	}
+	afterRollup := coverage.RollupByCrate(after, crateOf)
+
+	deltas := make([]report.CrateDelta, 0, len(afterRollup))
+	for _, c := range afterRollup {
+		deltas = append(deltas, report.CrateDelta{
+			Crate:         c.Crate,
+			BeforePercent: beforeByCrate[c.Crate],
+			AfterPercent:  c.Percent(),
+		})
+	}
+	return deltas, nil
+}
+
+// writeActionsSummary appends result's markdown rendering to
+// $GITHUB_STEP_SUMMARY and writes coverage_before/coverage_after/pr_needed
+// to $GITHUB_OUTPUT, if those Actions-provided files are set, so a
+// workflow can branch on this run's outcome without parsing
+// reportOutputFile itself. A no-op outside Actions, where neither env var
+// is set.
+func writeActionsSummary(result report.Result, before, after coverage.FileStats) error {
+	if summaryPath := os.Getenv("GITHUB_STEP_SUMMARY"); summaryPath != "" {
+		f, err := os.OpenFile(summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return errors.Wrap(err, "open GITHUB_STEP_SUMMARY")
+		}
+		_, writeErr := f.WriteString(report.Markdown(result) + "\n")
+		closeErr := f.Close()
+		if writeErr != nil {
+			return errors.Wrap(writeErr, "write GITHUB_STEP_SUMMARY")
+		}
+		if closeErr != nil {
+			return errors.Wrap(closeErr, "close GITHUB_STEP_SUMMARY")
+		}
+	}
+
+	if outputPath := os.Getenv("GITHUB_OUTPUT"); outputPath != "" {
+		if err := appendGithubOutput(outputPath, map[string]string{
+			"coverage_before": fmt.Sprintf("%.2f", before.Percent()),
+			"coverage_after":  fmt.Sprintf("%.2f", after.Percent()),
+			"pr_needed":       strconv.FormatBool(after.Percent() > before.Percent()),
+		}); err != nil {
+			return errors.Wrap(err, "write GITHUB_OUTPUT")
+		}
+	}
+
+	return nil
+}
+
+// appendGithubOutput appends key=value lines to path (typically
+// $GITHUB_OUTPUT), the file-based mechanism Actions uses for step outputs
+// since the ::set-output:: workflow command was deprecated.
+func appendGithubOutput(path string, values map[string]string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, err := fmt.Fprintf(f, "%s=%s\n", k, values[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uncoveredFunctionRefs finds file's still-uncovered functions in the
+// coverage export at afterFile and links each to its declaration on
+// GitHub at sha, so a reviewer can jump straight to what manual follow-up
+// is still needed.
+func uncoveredFunctionRefs(afterFile, file, owner, repo, sha string) ([]report.UncoveredFunctionRef, error) {
+	after, err := loadFullReport(afterFile)
+	if err != nil {
+		return nil, err
+	}
+
+	uncovered, err := coverage.UncoveredFunctions(after, repoRoot, file)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]report.UncoveredFunctionRef, 0, len(uncovered))
+	for _, fn := range uncovered {
+		refs = append(refs, report.UncoveredFunctionRef{
+			Name: fn.Name,
+			Line: fn.Line,
+			URL:  githubLineURL(owner, repo, sha, fn.File, fn.Line),
+		})
+	}
+	return refs, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/report"
+)
+
+func TestAppendGithubOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "github_output")
+
+	if err := appendGithubOutput(path, map[string]string{"coverage_after": "80.00"}); err != nil {
+		t.Fatalf("appendGithubOutput: %v", err)
+	}
+	if err := appendGithubOutput(path, map[string]string{"coverage_before": "75.00"}); err != nil {
+		t.Fatalf("appendGithubOutput: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if want := "coverage_after=80.00\ncoverage_before=75.00\n"; string(data) != want {
+		t.Errorf("GITHUB_OUTPUT content = %q, want %q", data, want)
+	}
+}
+
+func TestWriteConsolidatedReportMergesAcrossCalls(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "coverage_report.md")
+
+	if err := writeConsolidatedReport(outputFile, "markdown", report.Result{File: "a.rs"}); err != nil {
+		t.Fatalf("writeConsolidatedReport(a.rs): %v", err)
+	}
+	if err := writeConsolidatedReport(outputFile, "markdown", report.Result{File: "b.rs"}); err != nil {
+		t.Fatalf("writeConsolidatedReport(b.rs): %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	for _, want := range []string{"## a.rs", "## b.rs"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("consolidated report = %q, want it to contain %q", data, want)
+		}
+	}
+}
+
+func TestWriteConsolidatedReportRejectsHTML(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "coverage_report.html")
+
+	if err := writeConsolidatedReport(outputFile, "html", report.Result{File: "a.rs"}); err == nil {
+		t.Error("writeConsolidatedReport(html) error = nil, want an error since consolidation only supports markdown/json")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/config"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/warnings"
+)
+
+var (
+	repoRoot      string
+	verbose       bool
+	configFile    string
+	cfg           *config.Config
+	warningsFile  string
+	runWarnings   = &warnings.Store{}
+	webhookURL    string
+	webhookSecret string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "coverage-tool",
+	Short: "Analyze coverage and generate tests for the nydus workspace",
+}
+
+// Execute runs the coverage-tool root command.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&repoRoot, "repo", ".", "path to the nydus workspace root")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "enable verbose logging")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "path or URL to a coverage-tool config (YAML, or TOML if it ends in .toml); its own 'extends' is followed to inherit an org-wide base policy")
+	rootCmd.PersistentFlags().StringVar(&warningsFile, "warnings-file", ".coverage-tool/warnings.json", "path to the structured warnings log shared across a run's analyze/generate/report steps")
+	rootCmd.PersistentFlags().StringVar(&webhookURL, "webhook-url", "", "URL to POST a JSON event to at stage boundaries (analysis done, generation done, validation pass/fail, report ready); empty disables")
+	rootCmd.PersistentFlags().StringVar(&webhookSecret, "webhook-secret", "", "HMAC-SHA256 secret to sign --webhook-url events with; empty sends unsigned events")
+
+	cobra.OnInitialize(func() {
+		if verbose {
+			logrus.SetLevel(logrus.DebugLevel)
+		}
+		if configFile != "" {
+			loaded, err := config.Load(configFile)
+			if err != nil {
+				logrus.Fatalf("load config: %v", err)
+			}
+			cfg = loaded
+		}
+
+		store, err := warnings.Open(warningsFile)
+		if err != nil {
+			logrus.Fatalf("open warnings store: %v", err)
+		}
+		runWarnings = store
+	})
+
+	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(explainCmd)
+	rootCmd.AddCommand(digestCmd)
+	rootCmd.AddCommand(prCommentCmd)
+	rootCmd.AddCommand(gateCmd)
+	rootCmd.AddCommand(annotateCmd)
+	rootCmd.AddCommand(badgeCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(failuresCmd)
+	rootCmd.AddCommand(publishCmd)
+	rootCmd.AddCommand(junitCmd)
+	rootCmd.AddCommand(notifyCmd)
+	rootCmd.AddCommand(imageEntrypointCmd)
+	rootCmd.AddCommand(compareModelsCmd)
+}
+
+// applyConfigDefault sets *dst to value if the flag flagName was not
+// explicitly passed on the command line and cfg (the loaded --config
+// policy, if any) provides a non-zero value, so an org-wide policy sets
+// the default but an explicit flag on the command line always wins.
+func applyConfigDefault(cmd *cobra.Command, flagName string, dst *string, value string) {
+	if value == "" || cmd.Flags().Changed(flagName) {
+		return
+	}
+	*dst = value
+}
+
+// applyConfigDefaultFloat is applyConfigDefault for *float64-valued policy
+// fields such as coverage thresholds.
+func applyConfigDefaultFloat(cmd *cobra.Command, flagName string, dst *float64, value *float64) {
+	if value == nil || cmd.Flags().Changed(flagName) {
+		return
+	}
+	*dst = *value
+}
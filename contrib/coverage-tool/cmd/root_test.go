@@ -0,0 +1,55 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyConfigDefaultOnlyAppliesWhenFlagUnset(t *testing.T) {
+	cmd := &cobra.Command{}
+	var backend string
+	cmd.Flags().StringVar(&backend, "backend", "unit", "")
+
+	applyConfigDefault(cmd, "backend", &backend, "snapshot")
+	if backend != "snapshot" {
+		t.Errorf("applyConfigDefault should set the default when the flag was not passed, got %q", backend)
+	}
+
+	if err := cmd.Flags().Set("backend", "unit"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	applyConfigDefault(cmd, "backend", &backend, "snapshot")
+	if backend != "unit" {
+		t.Errorf("applyConfigDefault should not override an explicitly passed flag, got %q", backend)
+	}
+}
+
+func TestApplyConfigDefaultFloatOnlyAppliesWhenFlagUnset(t *testing.T) {
+	cmd := &cobra.Command{}
+	var minCoverage float64
+	cmd.Flags().Float64Var(&minCoverage, "min-coverage", 0, "")
+
+	policy := 80.0
+	applyConfigDefaultFloat(cmd, "min-coverage", &minCoverage, &policy)
+	if minCoverage != 80.0 {
+		t.Errorf("applyConfigDefaultFloat should set the default when the flag was not passed, got %v", minCoverage)
+	}
+
+	if err := cmd.Flags().Set("min-coverage", "50"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	applyConfigDefaultFloat(cmd, "min-coverage", &minCoverage, &policy)
+	if minCoverage != 50.0 {
+		t.Errorf("applyConfigDefaultFloat should not override an explicitly passed flag, got %v", minCoverage)
+	}
+
+	applyConfigDefaultFloat(cmd, "min-coverage", &minCoverage, nil)
+	if minCoverage != 50.0 {
+		t.Errorf("applyConfigDefaultFloat should be a no-op when the policy has no value, got %v", minCoverage)
+	}
+}
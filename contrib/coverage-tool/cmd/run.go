@@ -0,0 +1,103 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runSkipAnalyze  bool
+	runSkipGenerate bool
+	runSkipValidate bool
+	runSkipReport   bool
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Perform analyze, generate, validate, and report in one invocation",
+	Long: `run chains analyze, generate, validate, and report into a single
+pipeline, so a target file goes from candidate selection to a posted
+report without four separate workflow-step invocations threading flags
+and coverage exports between them by hand. It accepts every flag those
+four commands do; a flag two of them share (e.g. --file, --backend,
+--history-file) is set once here and applied to every stage that reads
+it. Any stage failing stops the pipeline before the stages that follow
+it, wrapped with which stage failed. Use --skip-analyze,
+--skip-generate, --skip-validate, or --skip-report to drop a stage a
+caller already ran separately (e.g. a CI job that measures coverage and
+calls analyze itself before deciding which file to pass to run).`,
+	RunE: runRun,
+}
+
+func init() {
+	runCmd.Flags().AddFlagSet(analyzeCmd.Flags())
+	runCmd.Flags().AddFlagSet(generateCmd.Flags())
+	runCmd.Flags().AddFlagSet(validateCmd.Flags())
+	runCmd.Flags().AddFlagSet(reportCmd.Flags())
+
+	runCmd.Flags().BoolVar(&runSkipAnalyze, "skip-analyze", false, "skip the analyze stage, e.g. if a caller already ran it to pick --file")
+	runCmd.Flags().BoolVar(&runSkipGenerate, "skip-generate", false, "skip the generate stage, e.g. to validate and report on a test written by hand")
+	runCmd.Flags().BoolVar(&runSkipValidate, "skip-validate", false, "skip the validate stage, e.g. if generate's own candidate validation is trusted as the only gate")
+	runCmd.Flags().BoolVar(&runSkipReport, "skip-report", false, "skip the report stage")
+}
+
+// syncSharedFlags propagates each flag two or more stages define under
+// the same name (and mean the same thing for) to every stage's own
+// variable, since AddFlagSet binds a shared name to only the first
+// stage's variable and silently drops the rest.
+func syncSharedFlags() {
+	generateBackend = analyzeBackend
+	validateBackend = analyzeBackend
+
+	validateFile = generateFile
+	reportFile = generateFile
+
+	generateCoverageFile = analyzeCoverageFile
+	generateContextWindow = analyzeContextWindow
+	generateReserveTokens = analyzeReserveTokens
+
+	generateHistoryFile = analyzeHistoryFile
+	reportHistoryFile = analyzeHistoryFile
+
+	reportGroupByCrate = analyzeGroupByCrate
+	reportGithubOwner = analyzeGithubOwner
+	reportGithubRepo = analyzeGithubRepo
+	reportSHA = analyzeSHA
+
+	validateCrate = generateValidateCrate
+	validateRunner = generateValidateRunner
+}
+
+func runRun(cmd *cobra.Command, _ []string) error {
+	syncSharedFlags()
+
+	if !runSkipAnalyze {
+		if err := runAnalyze(cmd, nil); err != nil {
+			return errors.Wrap(err, "analyze stage")
+		}
+	}
+
+	if !runSkipGenerate {
+		if err := runGenerate(cmd, nil); err != nil {
+			return errors.Wrap(err, "generate stage")
+		}
+	}
+
+	if !runSkipValidate {
+		if err := runValidate(cmd, nil); err != nil {
+			return errors.Wrap(err, "validate stage")
+		}
+	}
+
+	if !runSkipReport {
+		if err := runReport(cmd, nil); err != nil {
+			return errors.Wrap(err, "report stage")
+		}
+	}
+
+	return nil
+}
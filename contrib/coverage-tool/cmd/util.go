@@ -0,0 +1,440 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/codeowners"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/term"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/webhook"
+)
+
+// emitWebhookEvent posts a stage-boundary event to --webhook-url, if set;
+// a delivery failure is logged as a warning rather than failing the
+// command, since a dashboard being unreachable shouldn't block a
+// coverage-tool run.
+func emitWebhookEvent(stage, file string, data map[string]any) {
+	if webhookURL == "" {
+		return
+	}
+
+	event := webhook.Event{Stage: stage, Timestamp: time.Now(), File: file, Data: data}
+	if err := webhook.Send(webhookURL, webhookSecret, event); err != nil {
+		runWarnings.Warnf("failed to send %s webhook event: %v", stage, err)
+	}
+}
+
+// printCrateRollup prints one table row per Cargo workspace member covered
+// by files, aggregating each crate's line coverage across all of its files,
+// so maintainers thinking in crates don't have to add up individual files
+// by hand.
+func printCrateRollup(files []coverage.FileStats, colorEnabled bool) {
+	table := term.NewTable("Crate", "Coverage", "Lines")
+	for _, c := range coverage.RollupByCrate(files, func(path string) string { return cratePackageName(repoRoot, path) }) {
+		table.AddRow(c.Crate, term.Coverage(colorEnabled, c.Percent(), fmt.Sprintf("%.2f%%", c.Percent())), fmt.Sprintf("%d/%d", c.LinesCovered, c.LinesTotal))
+	}
+	table.Render(os.Stdout)
+}
+
+// resolveSHA returns override if set, so an explicit `--sha` always wins
+// (e.g. pinning a report to the SHA a CI job actually checked out);
+// otherwise it resolves repoRoot's current commit via `git rev-parse
+// HEAD`, so permalinks work out of the box without every caller having to
+// thread a SHA through.
+func resolveSHA(repoRoot, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = repoRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, "git rev-parse HEAD")
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// githubBlobURL returns a permalink to path at sha in owner/repo, pinned
+// to the exact commit rather than a branch so the link keeps working (and
+// keeps pointing at the reviewed code) even after later pushes.
+func githubBlobURL(owner, repo, sha, path string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", owner, repo, sha, path)
+}
+
+// githubLineURL is githubBlobURL with a #L<line> fragment linking straight
+// to a single line, e.g. an uncovered function's declaration.
+func githubLineURL(owner, repo, sha, path string, line int) string {
+	return fmt.Sprintf("%s#L%d", githubBlobURL(owner, repo, sha, path), line)
+}
+
+// loadReport parses the cargo llvm-cov JSON export at coverageFile.
+func loadReport(coverageFile string) ([]coverage.FileStats, error) {
+	f, err := os.Open(coverageFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "open coverage file")
+	}
+	defer f.Close()
+
+	report, err := coverage.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return report.Files, nil
+}
+
+// loadFullReport parses the cargo llvm-cov JSON export at coverageFile,
+// keeping its per-function execution data, for callers that need more than
+// loadReport's per-file summaries (e.g. UncoveredFunctions).
+func loadFullReport(coverageFile string) (*coverage.Report, error) {
+	f, err := os.Open(coverageFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "open coverage file")
+	}
+	defer f.Close()
+
+	return coverage.Parse(f)
+}
+
+// loadFileStats parses the coverage export at coverageFile and returns the
+// stats for target, or a zero-value FileStats if the file has no entry.
+func loadFileStats(coverageFile, target string) (coverage.FileStats, error) {
+	files, err := loadReport(coverageFile)
+	if err != nil {
+		return coverage.FileStats{}, err
+	}
+
+	for _, file := range files {
+		if file.Path == target {
+			return file, nil
+		}
+	}
+
+	return coverage.FileStats{Path: target}, nil
+}
+
+// resolveCrate returns override if set, so a `--validate-crate` flag
+// always wins; otherwise it returns the Cargo package name of the
+// workspace member containing file relative to repoRoot, or "" if neither
+// is available, in which case backend.Backend.ValidateCommand falls back
+// to validating the whole workspace.
+func resolveCrate(repoRoot, file, override string) string {
+	if override != "" {
+		return override
+	}
+	if file == "" {
+		return ""
+	}
+	return cratePackageName(repoRoot, file)
+}
+
+// cratePackageName walks up from file's directory looking for the nearest
+// Cargo.toml with a [package] name, returning that name, or "" if none is
+// found.
+func cratePackageName(repoRoot, file string) string {
+	dir := filepath.Dir(filepath.Join(repoRoot, file))
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, "Cargo.toml")); err == nil {
+			if name := parseCargoPackageName(data); name != "" {
+				return name
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// parseCargoPackageName extracts the `name = "..."` value from a
+// Cargo.toml's [package] section.
+func parseCargoPackageName(data []byte) string {
+	inPackage := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inPackage = trimmed == "[package]"
+			continue
+		}
+		if !inPackage {
+			continue
+		}
+		if name, value, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(name) == "name" {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
+
+// checkCommand builds the `cargo check --tests` command used as a fast
+// pre-pass ahead of the full test run, so a non-compiling generation is
+// rejected in seconds instead of waiting on the whole suite, scoped to
+// crate via -p when resolved.
+func checkCommand(crate string) []string {
+	command := []string{"cargo", "check", "--tests"}
+	if crate != "" {
+		command = append(command, "-p", crate)
+	}
+	return command
+}
+
+// clippyCommand builds the `cargo clippy --tests -- -D warnings` command
+// used to gate generated tests on the same lints CI enforces, scoped to
+// crate via -p when resolved.
+func clippyCommand(crate string) []string {
+	command := []string{"cargo", "clippy", "--tests"}
+	if crate != "" {
+		command = append(command, "-p", crate)
+	}
+	return append(command, "--", "-D", "warnings")
+}
+
+// miriCommand builds the `cargo miri test` command used to catch UB in
+// generated tests exercising unsafe code paths, scoped to crate via -p when
+// resolved.
+func miriCommand(crate string) []string {
+	command := []string{"cargo", "miri", "test"}
+	if crate != "" {
+		command = append(command, "-p", crate)
+	}
+	return command
+}
+
+// crossCheckCommand builds the `cargo check --tests --target <target>`
+// command used to make sure a candidate compiles for target as well as the
+// host, scoped to crate via -p when resolved.
+func crossCheckCommand(crate, target string) []string {
+	command := []string{"cargo", "check", "--tests", "--target", target}
+	if crate != "" {
+		command = append(command, "-p", crate)
+	}
+	return command
+}
+
+// toolchainCheckCommand builds the `cargo +<toolchain> check --tests`
+// command used to make sure a candidate compiles on toolchain (typically
+// the repo's MSRV or "stable") as well as whatever toolchain is running the
+// rest of validation, scoped to crate via -p when resolved.
+func toolchainCheckCommand(crate, toolchain string) []string {
+	command := []string{"cargo", "+" + toolchain, "check", "--tests"}
+	if crate != "" {
+		command = append(command, "-p", crate)
+	}
+	return command
+}
+
+// readMSRV resolves the repo's minimum supported Rust version: the
+// workspace Cargo.toml's [workspace.package] rust-version, or failing that
+// rust-toolchain.toml's pinned channel, or "" if neither declares one.
+func readMSRV(repoRoot string) string {
+	if data, err := os.ReadFile(filepath.Join(repoRoot, "Cargo.toml")); err == nil {
+		if v := parseCargoRustVersion(data); v != "" {
+			return v
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(repoRoot, "rust-toolchain.toml")); err == nil {
+		if v := parseToolchainChannel(data); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseCargoRustVersion extracts the `rust-version = "..."` value from a
+// Cargo.toml's [workspace.package] or [package] section.
+func parseCargoRustVersion(data []byte) string {
+	inRelevantSection := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inRelevantSection = trimmed == "[workspace.package]" || trimmed == "[package]"
+			continue
+		}
+		if !inRelevantSection {
+			continue
+		}
+		if name, value, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(name) == "rust-version" {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
+
+// parseToolchainChannel extracts the `channel = "..."` value from a
+// rust-toolchain.toml's [toolchain] section.
+func parseToolchainChannel(data []byte) string {
+	inToolchain := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inToolchain = trimmed == "[toolchain]"
+			continue
+		}
+		if !inToolchain {
+			continue
+		}
+		if name, value, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(name) == "channel" {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
+
+// splitCommand splits a --pre-validate-command/--validate-command flag
+// value on whitespace, or returns nil for "" (no quoting support).
+func splitCommand(command string) []string {
+	if command == "" {
+		return nil
+	}
+	return strings.Fields(command)
+}
+
+// validateCommandOverride returns splitCommand(override), or fallback
+// (typically b.ValidateCommand(crate, runner)) if override is "".
+func validateCommandOverride(override string, fallback []string) []string {
+	if override == "" {
+		return fallback
+	}
+	return splitCommand(override)
+}
+
+// featureGateRegexp matches a `feature = "name"` clause of a #[cfg(...)]
+// attribute, including ones nested in a multi-clause all(...)/any(...) gate.
+var featureGateRegexp = regexp.MustCompile(`feature\s*=\s*"([^"]+)"`)
+
+// resolveFeatures returns the sorted, de-duplicated Cargo feature names that
+// gate file's code behind #[cfg(feature = "...")], or nil if file can't be
+// read or gates none, so a caller building validation commands can pass the
+// right --features and actually exercise that code instead of silently
+// compiling it out.
+func resolveFeatures(file string) []string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	return detectFeatures(string(data))
+}
+
+// detectFeatures extracts the sorted, de-duplicated set of feature names
+// referenced by #[cfg(feature = "...")] attributes in fileContent.
+func detectFeatures(fileContent string) []string {
+	seen := make(map[string]struct{})
+	for _, m := range featureGateRegexp.FindAllStringSubmatch(fileContent, -1) {
+		seen[m[1]] = struct{}{}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	features := make([]string, 0, len(seen))
+	for f := range seen {
+		features = append(features, f)
+	}
+	slices.Sort(features)
+	return features
+}
+
+// withFeatures appends `--features <comma-joined features>` to command, if
+// both are non-empty, so validation of feature-gated code actually compiles
+// and runs it instead of silently skipping it.
+func withFeatures(command []string, features []string) []string {
+	if len(command) == 0 || len(features) == 0 {
+		return command
+	}
+	return append(command, "--features", strings.Join(features, ","))
+}
+
+// smokeTestCommand returns splitCommand(override), or the default `make
+// smoke` invocation, if file matches one of paths (filepath.Match-style glob
+// patterns evaluated against file's path relative to repoRoot); nil if paths
+// is empty or none match, so the extra integration-test tier only runs for
+// files in critical paths (e.g. the FUSE server) where UT alone isn't enough
+// confidence.
+func smokeTestCommand(file string, paths []string, override string) []string {
+	matched := false
+	for _, pattern := range paths {
+		if ok, _ := filepath.Match(pattern, file); ok {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return nil
+	}
+	if override != "" {
+		return splitCommand(override)
+	}
+	return []string{"make", "smoke"}
+}
+
+// asyncFnRegexp matches an `async fn` item; visibility/unsafe qualifiers
+// precede "async" in valid Rust syntax (e.g. "pub async fn"), so they need
+// no special handling here.
+var asyncFnRegexp = regexp.MustCompile(`\basync\s+fn\b`)
+
+// detectAsync reports whether fileContent declares any async fn, so a caller
+// can tell the model to write #[tokio::test] tests for it instead of plain
+// #[test] ones that would otherwise fail to compile against an .await.
+func detectAsync(fileContent string) bool {
+	return asyncFnRegexp.MatchString(fileContent)
+}
+
+// withFeaturesAll applies withFeatures to every command in commands.
+func withFeaturesAll(commands [][]string, features []string) [][]string {
+	if len(features) == 0 {
+		return commands
+	}
+	out := make([][]string, len(commands))
+	for i, command := range commands {
+		out[i] = withFeatures(command, features)
+	}
+	return out
+}
+
+// mutantsCommand builds the `cargo mutants --file <file>` command used to
+// measure how many mutants introduced into file the candidate's tests
+// catch, scoped to the target file so a workspace-wide mutation run isn't
+// needed for every candidate.
+func mutantsCommand(file string) []string {
+	return []string{"cargo", "mutants", "--no-shuffle", "--file", file}
+}
+
+// filterByOwner returns the subset of files owned by owner, per the
+// workspace's .github/CODEOWNERS file.
+func filterByOwner(files []coverage.FileStats, owner string) ([]coverage.FileStats, error) {
+	f, err := os.Open(filepath.Join(repoRoot, ".github", "CODEOWNERS"))
+	if err != nil {
+		return nil, errors.Wrap(err, "open CODEOWNERS")
+	}
+	defer f.Close()
+
+	rules, err := codeowners.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []coverage.FileStats
+	for _, file := range files {
+		if slices.Contains(rules.OwnersFor(file.Path), owner) {
+			owned = append(owned, file)
+		}
+	}
+
+	return owned, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/backend"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/candidate"
+)
+
+var (
+	validateBackend  string
+	validateFile     string
+	validateCrate    string
+	validateRunner   string
+	validateWorktree bool
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Run the workspace test suite against the current working tree",
+	RunE:  runValidate,
+}
+
+func init() {
+	validateCmd.Flags().StringVar(&validateBackend, "backend", "unit", "generation backend the tests being validated were produced by (unit, snapshot)")
+	validateCmd.Flags().StringVar(&validateFile, "file", "", "target source file the tests being validated cover, used to scope validation to its Cargo workspace member; omit to validate the whole workspace")
+	validateCmd.Flags().StringVar(&validateCrate, "validate-crate", "", "Cargo package name to scope validation to via `cargo test -p`, overriding the workspace member resolved from --file")
+	validateCmd.Flags().StringVar(&validateRunner, "validate-runner", "cargo", "test runner to validate with (cargo, nextest)")
+	validateCmd.Flags().BoolVar(&validateWorktree, "worktree", false, "validate --file's content in a disposable git worktree instead of the current checkout, only writing it back to --file if validation passes; the real checkout is never touched by a failing or crashed attempt, at the cost of a full rebuild in the worktree. Requires --file")
+}
+
+func runValidate(cmd *cobra.Command, _ []string) error {
+	if cfg != nil {
+		applyConfigDefault(cmd, "backend", &validateBackend, cfg.Provider)
+		applyConfigDefault(cmd, "validate-runner", &validateRunner, cfg.ValidateRunner)
+	}
+
+	b, err := backend.Get(validateBackend)
+	if err != nil {
+		return err
+	}
+
+	runner, err := backend.ParseRunner(validateRunner)
+	if err != nil {
+		return err
+	}
+
+	crate := resolveCrate(repoRoot, validateFile, validateCrate)
+
+	if validateWorktree {
+		if validateFile == "" {
+			return errors.New("--worktree requires --file")
+		}
+		err := runValidateInWorktree(crate, b, runner)
+		emitValidationEvent(err)
+		return err
+	}
+
+	run := func() error {
+		logrus.Info("Running cargo check --tests...")
+		if err := runStreamed(checkCommand(crate)); err != nil {
+			return err
+		}
+
+		command := b.ValidateCommand(crate, runner)
+		logrus.Infof("Running %s...", strings.Join(command, " "))
+		return runStreamed(command)
+	}
+
+	if validateFile == "" {
+		err = run()
+	} else {
+		err = runValidateWithBackup(repoRoot, validateFile, run)
+	}
+	emitValidationEvent(err)
+	return err
+}
+
+// runValidateWithBackup runs run guarded by backupRestore over file resolved
+// against repoRoot, so a SIGINT/SIGTERM/panic during a long test run never
+// leaves file partially rewritten regardless of the process's actual working
+// directory, which --repo need not match.
+func runValidateWithBackup(repoRoot, file string, run func() error) error {
+	return backupRestore(filepath.Join(repoRoot, file), run)
+}
+
+// emitValidationEvent reports validate's outcome as a "validation-pass" or
+// "validation-fail" webhook event, so a dashboard doesn't need to poll
+// coverage-tool's exit code.
+func emitValidationEvent(err error) {
+	stage := "validation-pass"
+	data := map[string]any{}
+	if err != nil {
+		stage = "validation-fail"
+		data["error"] = err.Error()
+	}
+	emitWebhookEvent(stage, validateFile, data)
+}
+
+// runValidateInWorktree copies validateFile's current content into a
+// disposable git worktree checked out from HEAD, runs the same check and
+// validate commands there, and only overwrites the real validateFile with
+// that content if both succeed — so a failing or crashed attempt leaves the
+// caller's checkout exactly as it was before validate ran.
+func runValidateInWorktree(crate string, b backend.Backend, runner backend.Runner) error {
+	content, err := os.ReadFile(filepath.Join(repoRoot, validateFile))
+	if err != nil {
+		return errors.Wrapf(err, "read %s", validateFile)
+	}
+
+	worktree, cleanup, err := candidate.Worktree(repoRoot)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	target := filepath.Join(worktree, validateFile)
+	if err := os.WriteFile(target, content, 0o644); err != nil {
+		return errors.Wrapf(err, "write %s", target)
+	}
+
+	logrus.Info("Running cargo check --tests in worktree...")
+	if err := runStreamedIn(worktree, checkCommand(crate)); err != nil {
+		return err
+	}
+
+	command := b.ValidateCommand(crate, runner)
+	logrus.Infof("Running %s in worktree...", strings.Join(command, " "))
+	if err := runStreamedIn(worktree, command); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(repoRoot, validateFile), content, 0o644); err != nil {
+		return errors.Wrapf(err, "apply validated %s to checkout", validateFile)
+	}
+	return nil
+}
+
+// backupRestore snapshots path's content before calling run, and restores
+// it if run panics or the process receives SIGINT/SIGTERM while run is
+// executing, so a CI runner that kills validate mid-attempt never leaves
+// the target file in a partially rewritten state. A normal return from run,
+// successful or not, leaves path as run left it.
+func backupRestore(path string, run func() error) error {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return run()
+	}
+	restore := func() { _ = os.WriteFile(path, original, 0o644) }
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+			restore()
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+	defer close(done)
+
+	defer func() {
+		if r := recover(); r != nil {
+			restore()
+			panic(r)
+		}
+	}()
+
+	return run()
+}
+
+// runStreamed runs command from repoRoot with its stdout/stderr connected to
+// the terminal, for the interactive `validate` command.
+func runStreamed(command []string) error {
+	return runStreamedIn(repoRoot, command)
+}
+
+// runStreamedIn runs command from dir with its stdout/stderr connected to
+// the terminal.
+func runStreamedIn(dir string, command []string) error {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "%s", command)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupRestoreLeavesNormalReturnUntouched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "device.rs")
+	if err := os.WriteFile(path, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := backupRestore(path, func() error {
+		return os.WriteFile(path, []byte("mutated"), 0o644)
+	})
+	if err != nil {
+		t.Fatalf("backupRestore() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "mutated" {
+		t.Errorf("file content = %q, want %q (a normal return should not restore)", data, "mutated")
+	}
+}
+
+func TestBackupRestoreRestoresOnPanic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "device.rs")
+	if err := os.WriteFile(path, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("backupRestore() should re-panic after restoring")
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "original" {
+			t.Errorf("file content = %q, want %q (a panic should restore)", data, "original")
+		}
+	}()
+
+	_ = backupRestore(path, func() error {
+		_ = os.WriteFile(path, []byte("mutated"), 0o644)
+		panic("boom")
+	})
+}
+
+func TestRunValidateWithBackupResolvesFileAgainstRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "device.rs")
+	if err := os.WriteFile(path, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The test process's actual cwd (the cmd package directory) has no
+	// "device.rs", so if runValidateWithBackup ever regresses to resolving
+	// file relative to cwd instead of repoRoot, backupRestore's ReadFile
+	// fails and it silently skips backup/restore, leaving "mutated" in
+	// place after the panic below instead of restoring "original".
+	defer func() {
+		_ = recover()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "original" {
+			t.Errorf("file content = %q, want %q (repoRoot-relative file should have been backed up and restored)", data, "original")
+		}
+	}()
+
+	_ = runValidateWithBackup(root, "device.rs", func() error {
+		_ = os.WriteFile(path, []byte("mutated"), 0o644)
+		panic("boom")
+	})
+}
+
+func TestBackupRestoreRunsDirectlyWhenFileMissing(t *testing.T) {
+	called := false
+	err := backupRestore(filepath.Join(t.TempDir(), "missing.rs"), func() error {
+		called = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("backupRestore() error = %v", err)
+	}
+	if !called {
+		t.Error("backupRestore() should still call run when the file can't be read")
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command coverage-tool analyzes Rust code coverage for the nydus workspace,
+// uses a language model to generate tests for poorly covered files, validates
+// the generated tests against the workspace test suite, and reports the
+// resulting coverage improvement.
+package main
+
+import (
+	"os"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/cmd"
+)
+
+func main() {
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit writes an append-only JSONL log of every prompt sent to,
+// and response received from, an llm provider during a run, so a security
+// review can verify exactly what source code left the machine and when.
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+)
+
+// Entry is a single audited prompt/response pair.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	File      string    `json:"file"`
+	Model     string    `json:"model"`
+	Prompt    string    `json:"prompt"`
+	Response  string    `json:"response"`
+	Usage     llm.Usage `json:"usage"`
+}
+
+// Logger appends Entry records to a JSONL file. Unlike coverage-tool's
+// other file-backed stores (history, cost, ratelimit), which rewrite a
+// small JSON document on every update, Logger keeps its file open and
+// appends one line per call, since prompts and responses can be too large
+// to comfortably read-modify-write on every record.
+type Logger struct {
+	f *os.File
+}
+
+// Open opens (creating if necessary) the JSONL audit log at path for
+// appending.
+func Open(path string) (*Logger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.Wrap(err, "create audit log directory")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errors.Wrap(err, "open audit log")
+	}
+
+	return &Logger{f: f}, nil
+}
+
+// Record appends one Entry to the log.
+func (l *Logger) Record(file, model, prompt, response string, usage llm.Usage) error {
+	entry := Entry{
+		Timestamp: time.Now(),
+		File:      file,
+		Model:     model,
+		Prompt:    prompt,
+		Response:  response,
+		Usage:     usage,
+	}
+
+	return errors.Wrap(json.NewEncoder(l.f).Encode(entry), "write audit log entry")
+}
+
+// Close closes the underlying file.
+func (l *Logger) Close() error {
+	return l.f.Close()
+}
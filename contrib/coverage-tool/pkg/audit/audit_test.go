@@ -0,0 +1,88 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+)
+
+func TestRecordAppendsOneJSONLinePerCall(t *testing.T) {
+	path := t.TempDir() + "/audit.jsonl"
+
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := l.Record("storage/src/device.rs", "gpt-4o-mini", "prompt one", "response one", llm.Usage{PromptTokens: 10, CompletionTokens: 5}); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if err := l.Record("storage/src/device.rs", "gpt-4o-mini", "prompt two", "response two", llm.Usage{}); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open log file returned error: %v", err)
+	}
+	defer f.Close()
+
+	var lines []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		lines = append(lines, entry)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if lines[0].Prompt != "prompt one" || lines[1].Prompt != "prompt two" {
+		t.Errorf("lines = %+v, want prompts in order", lines)
+	}
+}
+
+func TestOpenAppendsToExistingLog(t *testing.T) {
+	path := t.TempDir() + "/audit.jsonl"
+
+	l1, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	_ = l1.Record("a.rs", "m", "p", "r", llm.Usage{})
+	_ = l1.Close()
+
+	l2, err := Open(path)
+	if err != nil {
+		t.Fatalf("re-Open returned error: %v", err)
+	}
+	_ = l2.Record("b.rs", "m", "p", "r", llm.Usage{})
+	_ = l2.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	count := 0
+	for scanner.Scan() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("got %d lines after reopening, want 2", count)
+	}
+}
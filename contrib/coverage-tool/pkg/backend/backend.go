@@ -0,0 +1,216 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package backend defines the kinds of tests coverage-tool can ask the
+// model to generate for a target file, and how each kind is prompted for,
+// integrated, and validated.
+package backend
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PromptOptions carries prompt-shaping choices that apply across every
+// backend, so new ones (this package's own "explain existing tests to the
+// model" or similar) don't require another BuildPrompt signature change.
+type PromptOptions struct {
+	// PublicOnly restricts the model to testing pub items of the target
+	// file, for teams whose policy forbids testing private internals.
+	PublicOnly bool
+	// Exemplars are rendered "// --- path ---\n<contents>" blocks for
+	// well-tested sibling files, included as few-shot style examples so
+	// generated tests follow the crate's existing testing conventions.
+	Exemplars []string
+	// ExistingTests are the names of tests already present in the target
+	// file's #[cfg(test)] module, so the model is told not to duplicate
+	// them.
+	ExistingTests []string
+	// Async signals that the target file has async fn(s), so the model is
+	// told to use #[tokio::test] (with an explicit flavor) instead of plain
+	// #[test] for tests exercising them.
+	Async bool
+}
+
+// Runner selects the test runner Backend.ValidateCommand builds a command
+// for.
+type Runner string
+
+const (
+	// CargoRunner validates with a plain `cargo test`/`make ut`/`cargo
+	// insta test` invocation.
+	CargoRunner Runner = "cargo"
+	// NextestRunner validates with `cargo nextest run`, whose JSON event
+	// stream lets the caller identify exactly which test failed instead
+	// of just that the command exited non-zero.
+	NextestRunner Runner = "nextest"
+)
+
+// ParseRunner parses a --validate-runner flag value, defaulting to
+// CargoRunner for "".
+func ParseRunner(name string) (Runner, error) {
+	switch Runner(name) {
+	case "", CargoRunner:
+		return CargoRunner, nil
+	case NextestRunner:
+		return NextestRunner, nil
+	default:
+		return "", fmt.Errorf("unknown validate runner %q", name)
+	}
+}
+
+// Backend produces prompts for a particular style of generated test and
+// declares what the target crate needs (dev-dependencies, validation
+// commands) to accept that style.
+type Backend interface {
+	// Name identifies the backend for --backend flags and reports.
+	Name() string
+	// BuildPrompt returns the model prompt for fileContent, given the
+	// surrounding module context and opts.
+	BuildPrompt(fileContent, moduleContext string, opts PromptOptions) string
+	// DevDependencies lists Cargo dev-dependencies the target crate must
+	// have for the generated tests to compile.
+	DevDependencies() []string
+	// ValidateCommand is the shell command used to accept/validate tests
+	// produced by this backend, run from the crate root. crate is the
+	// Cargo package name of the workspace member the target file belongs
+	// to, or "" if it couldn't be resolved, in which case the command
+	// falls back to validating the whole workspace. runner selects
+	// between a plain cargo invocation and cargo-nextest.
+	ValidateCommand(crate string, runner Runner) []string
+}
+
+// publicOnlyInstruction is appended to a backend's prompt when the caller
+// asked to restrict generated tests to the target file's public API.
+const publicOnlyInstruction = "\n\nOnly write tests for `pub` items of the target file; do not test private functions or internals."
+
+// asyncInstruction is appended to a backend's prompt when the target file
+// has async fn(s), so tests exercising them use a real Tokio runtime instead
+// of failing to compile against a plain #[test].
+const asyncInstruction = "\n\nThe target file has async fn(s); write tests for them with #[tokio::test(flavor = \"multi_thread\")] instead of plain #[test], and await their results directly in the test body."
+
+// exemplarsSection renders opts.Exemplars as a prompt section demonstrating
+// the crate's existing testing style, or "" if there are none.
+func exemplarsSection(opts PromptOptions) string {
+	if len(opts.Exemplars) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(
+		"\n\nHere are examples of well-tested files from this crate; match their testing style:\n\n%s",
+		strings.Join(opts.Exemplars, "\n"),
+	)
+}
+
+// existingTestsSection lists the names of tests already present in the
+// target file, so the model doesn't waste a completion regenerating them,
+// or "" if opts.ExistingTests is empty.
+func existingTestsSection(opts PromptOptions) string {
+	if len(opts.ExistingTests) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(
+		"\n\nThe file already has the following tests; do not regenerate them, only write "+
+			"new tests covering behavior they don't already cover:\n%s",
+		strings.Join(opts.ExistingTests, "\n"),
+	)
+}
+
+// Unit generates ordinary #[cfg(test)] unit tests. It is the default
+// backend and requires no extra dev-dependencies.
+type Unit struct{}
+
+func (Unit) Name() string { return "unit" }
+
+func (Unit) BuildPrompt(fileContent, moduleContext string, opts PromptOptions) string {
+	prompt := fmt.Sprintf(
+		"You are generating Rust unit tests for the following file.\n\nModule context:\n%s\n\nTarget file:\n%s\n\nWrite a #[cfg(test)] module with additional tests improving coverage.",
+		moduleContext, fileContent,
+	)
+	if opts.PublicOnly {
+		prompt += publicOnlyInstruction
+	}
+	if opts.Async {
+		prompt += asyncInstruction
+	}
+	prompt += existingTestsSection(opts)
+	prompt += exemplarsSection(opts)
+	return prompt
+}
+
+func (Unit) DevDependencies() []string { return nil }
+
+// ValidateCommand runs just the target crate's tests via `cargo test -p`
+// instead of `make ut`'s full-workspace rebuild, which takes several
+// minutes longer per attempt on a workspace this size. With NextestRunner,
+// it runs `cargo nextest run` instead, whose libtest-json event stream
+// pkg/candidate parses to report exactly which test failed.
+func (Unit) ValidateCommand(crate string, runner Runner) []string {
+	if runner == NextestRunner {
+		command := []string{"cargo", "nextest", "run", "--no-fail-fast", "--message-format", "libtest-json-plus"}
+		if crate != "" {
+			command = append(command, "-p", crate)
+		}
+		return command
+	}
+	if crate == "" {
+		return []string{"make", "ut"}
+	}
+	return []string{"cargo", "test", "-p", crate}
+}
+
+// Snapshot generates insta (https://insta.rs) snapshot tests for functions
+// that return a Serialize or Display type, letting the model assert on the
+// full shape of a value instead of hand-picking fields.
+type Snapshot struct{}
+
+func (Snapshot) Name() string { return "snapshot" }
+
+func (Snapshot) BuildPrompt(fileContent, moduleContext string, opts PromptOptions) string {
+	prompt := fmt.Sprintf(
+		"You are generating Rust snapshot tests using the `insta` crate for the following file.\n\n"+
+			"Module context:\n%s\n\nTarget file:\n%s\n\n"+
+			"For every function returning a type implementing Serialize or Display, write a #[cfg(test)] "+
+			"module using insta::assert_debug_snapshot! or insta::assert_display_snapshot! as appropriate. "+
+			"Do not hand-write expected values; leave the snapshot to be recorded by `cargo insta review`.",
+		moduleContext, fileContent,
+	)
+	if opts.PublicOnly {
+		prompt += publicOnlyInstruction
+	}
+	if opts.Async {
+		prompt += asyncInstruction
+	}
+	prompt += existingTestsSection(opts)
+	prompt += exemplarsSection(opts)
+	return prompt
+}
+
+func (Snapshot) DevDependencies() []string { return []string{"insta"} }
+
+// ValidateCommand scopes `cargo insta test` to crate via -p, when resolved.
+// With NextestRunner, it delegates test execution to cargo-nextest via
+// --test-runner nextest, so failures still come back as libtest-json events.
+func (Snapshot) ValidateCommand(crate string, runner Runner) []string {
+	command := []string{"cargo", "insta", "test", "--accept"}
+	if runner == NextestRunner {
+		command = append(command, "--test-runner", "nextest")
+	}
+	if crate != "" {
+		command = append(command, "-p", crate)
+	}
+	return command
+}
+
+// Get returns the backend registered under name, or an error if name is
+// unknown.
+func Get(name string) (Backend, error) {
+	switch name {
+	case "", "unit":
+		return Unit{}, nil
+	case "snapshot":
+		return Snapshot{}, nil
+	default:
+		return nil, fmt.Errorf("unknown generation backend %q", name)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package backend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	if b, err := Get(""); err != nil || b.Name() != "unit" {
+		t.Errorf("Get(\"\") = %v, %v; want unit backend", b, err)
+	}
+	if b, err := Get("snapshot"); err != nil || b.Name() != "snapshot" {
+		t.Errorf("Get(\"snapshot\") = %v, %v; want snapshot backend", b, err)
+	}
+	if _, err := Get("bogus"); err == nil {
+		t.Error("Get(\"bogus\") should return an error")
+	}
+}
+
+func TestSnapshotDevDependencies(t *testing.T) {
+	deps := Snapshot{}.DevDependencies()
+	if len(deps) != 1 || deps[0] != "insta" {
+		t.Errorf("Snapshot.DevDependencies() = %v, want [insta]", deps)
+	}
+	if len(Unit{}.DevDependencies()) != 0 {
+		t.Error("Unit.DevDependencies() should be empty")
+	}
+}
+
+func TestUnitValidateCommandScopesToCrate(t *testing.T) {
+	if cmd := (Unit{}).ValidateCommand("", CargoRunner); len(cmd) != 2 || cmd[0] != "make" || cmd[1] != "ut" {
+		t.Errorf("Unit.ValidateCommand(\"\", CargoRunner) = %v, want [make ut]", cmd)
+	}
+
+	cmd := (Unit{}).ValidateCommand("nydus-storage", CargoRunner)
+	want := []string{"cargo", "test", "-p", "nydus-storage"}
+	if strings.Join(cmd, " ") != strings.Join(want, " ") {
+		t.Errorf("Unit.ValidateCommand(%q, CargoRunner) = %v, want %v", "nydus-storage", cmd, want)
+	}
+}
+
+func TestSnapshotValidateCommandScopesToCrate(t *testing.T) {
+	if cmd := (Snapshot{}).ValidateCommand("", CargoRunner); strings.Contains(strings.Join(cmd, " "), "-p") {
+		t.Errorf("Snapshot.ValidateCommand(\"\", CargoRunner) should not scope to a crate, got %v", cmd)
+	}
+
+	cmd := (Snapshot{}).ValidateCommand("nydus-storage", CargoRunner)
+	if !strings.Contains(strings.Join(cmd, " "), "-p nydus-storage") {
+		t.Errorf("Snapshot.ValidateCommand(%q, CargoRunner) = %v, want it scoped with -p", "nydus-storage", cmd)
+	}
+}
+
+func TestValidateCommandNextestRunner(t *testing.T) {
+	cmd := (Unit{}).ValidateCommand("nydus-storage", NextestRunner)
+	joined := strings.Join(cmd, " ")
+	if !strings.Contains(joined, "nextest") || !strings.Contains(joined, "-p nydus-storage") {
+		t.Errorf("Unit.ValidateCommand(%q, NextestRunner) = %v, want it to run cargo nextest scoped with -p", "nydus-storage", cmd)
+	}
+
+	cmd = (Snapshot{}).ValidateCommand("", NextestRunner)
+	if !strings.Contains(strings.Join(cmd, " "), "--test-runner nextest") {
+		t.Errorf("Snapshot.ValidateCommand(\"\", NextestRunner) = %v, want --test-runner nextest", cmd)
+	}
+}
+
+func TestParseRunner(t *testing.T) {
+	if r, err := ParseRunner(""); err != nil || r != CargoRunner {
+		t.Errorf("ParseRunner(\"\") = %v, %v; want CargoRunner", r, err)
+	}
+	if r, err := ParseRunner("nextest"); err != nil || r != NextestRunner {
+		t.Errorf("ParseRunner(\"nextest\") = %v, %v; want NextestRunner", r, err)
+	}
+	if _, err := ParseRunner("bogus"); err == nil {
+		t.Error("ParseRunner(\"bogus\") should return an error")
+	}
+}
+
+func TestBuildPromptPublicOnly(t *testing.T) {
+	prompt := Unit{}.BuildPrompt("fn f() {}", "", PromptOptions{PublicOnly: true})
+	if !strings.Contains(prompt, "pub") {
+		t.Errorf("BuildPrompt with PublicOnly should instruct the model to test pub items, got:\n%s", prompt)
+	}
+
+	prompt = Unit{}.BuildPrompt("fn f() {}", "", PromptOptions{})
+	if strings.Contains(prompt, "Only write tests for `pub` items") {
+		t.Error("BuildPrompt without PublicOnly should not add the pub-only instruction")
+	}
+}
+
+func TestBuildPromptExistingTests(t *testing.T) {
+	prompt := Unit{}.BuildPrompt("fn f() {}", "", PromptOptions{ExistingTests: []string{"test_f_zero"}})
+	if !strings.Contains(prompt, "test_f_zero") {
+		t.Errorf("BuildPrompt with ExistingTests should list them, got:\n%s", prompt)
+	}
+
+	prompt = Unit{}.BuildPrompt("fn f() {}", "", PromptOptions{})
+	if strings.Contains(prompt, "already has the following tests") {
+		t.Error("BuildPrompt without ExistingTests should not add the existing-tests section")
+	}
+}
+
+func TestBuildPromptAsync(t *testing.T) {
+	prompt := Unit{}.BuildPrompt("async fn f() {}", "", PromptOptions{Async: true})
+	if !strings.Contains(prompt, "tokio::test") {
+		t.Errorf("BuildPrompt with Async should instruct the model to use #[tokio::test], got:\n%s", prompt)
+	}
+
+	prompt = Unit{}.BuildPrompt("fn f() {}", "", PromptOptions{})
+	if strings.Contains(prompt, "tokio::test") {
+		t.Error("BuildPrompt without Async should not add the tokio::test instruction")
+	}
+}
+
+func TestBuildPromptExemplars(t *testing.T) {
+	prompt := Unit{}.BuildPrompt("fn f() {}", "", PromptOptions{Exemplars: []string{"// --- good.rs ---\nfn g() {}\n"}})
+	if !strings.Contains(prompt, "good.rs") {
+		t.Errorf("BuildPrompt with Exemplars should include them, got:\n%s", prompt)
+	}
+
+	prompt = Unit{}.BuildPrompt("fn f() {}", "", PromptOptions{})
+	if strings.Contains(prompt, "well-tested files") {
+		t.Error("BuildPrompt without Exemplars should not add the exemplars section")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package badge renders shields.io-style flat SVG badges for coverage
+// percentages, so overall and per-crate coverage can be published as
+// static images on the repo or gh-pages without depending on shields.io
+// at render time.
+package badge
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// charWidth approximates the average pixel width of a Verdana 11px
+// character, shields.io's font; badges only need to look right, not match
+// pixel-for-pixel, so a single constant stands in for real text metrics.
+const charWidth = 7
+
+// padding is the empty space added on both sides of each label/message
+// segment's text.
+const padding = 10
+
+// ColorFor maps a coverage percentage to a shields.io-style color name,
+// matching the badges GitHub Actions coverage workflows commonly use.
+func ColorFor(percent float64) string {
+	switch {
+	case percent >= 90:
+		return "brightgreen"
+	case percent >= 75:
+		return "green"
+	case percent >= 60:
+		return "yellowgreen"
+	case percent >= 40:
+		return "yellow"
+	case percent >= 25:
+		return "orange"
+	default:
+		return "red"
+	}
+}
+
+// colorHex maps a ColorFor color name to its shields.io hex value.
+var colorHex = map[string]string{
+	"brightgreen": "#4c1",
+	"green":       "#97ca00",
+	"yellowgreen": "#a4a61d",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+}
+
+// Render returns a flat, shields.io-style SVG badge with label and message
+// segments, message colored by color (one of ColorFor's return values).
+func Render(label, message, color string) string {
+	hex, ok := colorHex[color]
+	if !ok {
+		hex = color
+	}
+
+	labelWidth := len(label)*charWidth + padding*2
+	messageWidth := len(message)*charWidth + padding*2
+	width := labelWidth + messageWidth
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r">
+    <rect width="%d" height="20" rx="3" fill="#fff"/>
+  </clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%d" height="20" fill="#555"/>
+    <rect x="%d" width="%d" height="20" fill="%s"/>
+    <rect width="%d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,sans-serif" font-size="11">
+    <text x="%d" y="14">%s</text>
+    <text x="%d" y="14">%s</text>
+  </g>
+</svg>
+`, width, label, message, width, labelWidth, labelWidth, messageWidth, hex, width,
+		labelWidth/2, label, labelWidth+messageWidth/2, message)
+}
+
+// Write renders a coverage badge for percent, labeled label (e.g.
+// "coverage" or a crate name), and writes it to path.
+func Write(path, label string, percent float64) error {
+	message := fmt.Sprintf("%.1f%%", percent)
+	svg := Render(label, message, ColorFor(percent))
+	if err := os.WriteFile(path, []byte(svg), 0o644); err != nil {
+		return errors.Wrap(err, "write badge")
+	}
+	return nil
+}
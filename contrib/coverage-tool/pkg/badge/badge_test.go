@@ -0,0 +1,55 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package badge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestColorFor(t *testing.T) {
+	cases := []struct {
+		percent float64
+		want    string
+	}{
+		{95, "brightgreen"},
+		{80, "green"},
+		{65, "yellowgreen"},
+		{45, "yellow"},
+		{30, "orange"},
+		{10, "red"},
+	}
+	for _, c := range cases {
+		if got := ColorFor(c.percent); got != c.want {
+			t.Errorf("ColorFor(%v) = %q, want %q", c.percent, got, c.want)
+		}
+	}
+}
+
+func TestRenderIncludesLabelAndMessage(t *testing.T) {
+	svg := Render("coverage", "87.5%", "green")
+	for _, want := range []string{"<svg", "coverage", "87.5%", "#97ca00"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Render output = %s, want it to contain %q", svg, want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.svg")
+	if err := Write(path, "coverage", 42); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(data), "42.0%") {
+		t.Errorf("badge = %s, want it to contain \"42.0%%\"", data)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package candidate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Run records every candidate generated and evaluated for one --candidates
+// invocation, and which one (if any) was selected.
+type Run struct {
+	File      string    `json:"file"`
+	Timestamp time.Time `json:"timestamp"`
+	Results   []Result  `json:"results"`
+	// Selected is the index of the chosen candidate within Results, or -1
+	// if none was selected.
+	Selected int `json:"selected"`
+}
+
+// Artifacts is an append-only, file-backed log of past --candidates runs.
+type Artifacts struct {
+	path string
+	Runs []Run `json:"runs"`
+}
+
+// OpenArtifacts loads the artifact store at path, creating an empty one if
+// it does not yet exist.
+func OpenArtifacts(path string) (*Artifacts, error) {
+	a := &Artifacts{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return a, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read candidate artifacts")
+	}
+
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, errors.Wrap(err, "parse candidate artifacts")
+	}
+	a.path = path
+
+	return a, nil
+}
+
+// Record appends a run of results and persists the store to disk. selected
+// is the index of the chosen candidate within results, or -1 if none was
+// selected.
+func (a *Artifacts) Record(file string, results []Result, selected int) error {
+	a.Runs = append(a.Runs, Run{File: file, Timestamp: time.Now(), Results: results, Selected: selected})
+	return a.save()
+}
+
+func (a *Artifacts) save() error {
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o755); err != nil {
+		return errors.Wrap(err, "create candidate artifacts directory")
+	}
+
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal candidate artifacts")
+	}
+
+	return errors.Wrap(os.WriteFile(a.path, data, 0o644), "write candidate artifacts")
+}
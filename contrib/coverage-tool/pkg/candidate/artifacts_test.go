@@ -0,0 +1,28 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package candidate
+
+import "testing"
+
+func TestArtifactsRecordPersistsAcrossOpen(t *testing.T) {
+	path := t.TempDir() + "/candidates.json"
+
+	a, err := OpenArtifacts(path)
+	if err != nil {
+		t.Fatalf("OpenArtifacts returned error: %v", err)
+	}
+	results := []Result{{Index: 0, Valid: true, Delta: 3}}
+	if err := a.Record("storage/src/device.rs", results, 0); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+
+	reopened, err := OpenArtifacts(path)
+	if err != nil {
+		t.Fatalf("re-OpenArtifacts returned error: %v", err)
+	}
+	if len(reopened.Runs) != 1 || reopened.Runs[0].Selected != 0 {
+		t.Errorf("Runs = %+v, want one run with Selected 0", reopened.Runs)
+	}
+}
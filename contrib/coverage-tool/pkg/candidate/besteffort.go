@@ -0,0 +1,77 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package candidate
+
+import (
+	"strings"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/chunk"
+)
+
+// BestEffort returns the result across results most worth keeping when none
+// is Valid: the one with the fewest FailedTests (implying it at least
+// compiled and ran, just didn't pass every test), breaking ties by higher
+// CoveragePercent, so a caller can offer to apply it with its failing tests
+// stripped instead of discarding every attempt. ok is false if results is
+// empty.
+func BestEffort(results []Result) (Result, bool) {
+	if len(results) == 0 {
+		return Result{}, false
+	}
+
+	best := results[0]
+	for _, r := range results[1:] {
+		if betterEffort(r, best) {
+			best = r
+		}
+	}
+	return best, true
+}
+
+// betterEffort reports whether a is a more worthwhile best-effort result
+// than b: valid beats invalid, fewer failing tests beats more (a result
+// with no FailedTests recorded at all is treated as never having compiled,
+// so it ranks last), and higher coverage breaks remaining ties.
+func betterEffort(a, b Result) bool {
+	if a.Valid != b.Valid {
+		return a.Valid
+	}
+	if aRan, bRan := len(a.FailedTests) > 0, len(b.FailedTests) > 0; aRan != bRan {
+		return aRan
+	}
+	if len(a.FailedTests) != len(b.FailedTests) {
+		return len(a.FailedTests) < len(b.FailedTests)
+	}
+	return a.CoveragePercent > b.CoveragePercent
+}
+
+// StripFailingTests removes the named test functions from testCode, using
+// chunk.Split's brace-counting function extraction, so a candidate that
+// compiles but fails a handful of its generated tests can still be applied
+// with just those removed instead of discarding the whole attempt. Names
+// are matched against the last "::" segment of each failedTests entry,
+// since cargo-nextest reports them module-qualified (e.g. "tests::it_fails")
+// while chunk.Split extracts bare function names.
+func StripFailingTests(testCode string, failedTests []string) string {
+	if len(failedTests) == 0 {
+		return testCode
+	}
+
+	failing := make(map[string]bool, len(failedTests))
+	for _, name := range failedTests {
+		if i := strings.LastIndex(name, "::"); i >= 0 {
+			name = name[i+2:]
+		}
+		failing[name] = true
+	}
+
+	var kept []string
+	for _, fn := range chunk.Split(testCode) {
+		if !failing[fn.Name] {
+			kept = append(kept, fn.Body)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package candidate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBestEffortPrefersValid(t *testing.T) {
+	results := []Result{
+		{Index: 0, FailedTests: []string{"tests::a"}},
+		{Index: 1, Valid: true, CoveragePercent: 10},
+	}
+
+	best, ok := BestEffort(results)
+	if !ok {
+		t.Fatal("BestEffort() should find a result")
+	}
+	if best.Index != 1 {
+		t.Errorf("BestEffort().Index = %d, want 1", best.Index)
+	}
+}
+
+func TestBestEffortPrefersFewerFailingTests(t *testing.T) {
+	results := []Result{
+		{Index: 0, FailedTests: []string{"tests::a", "tests::b"}},
+		{Index: 1, FailedTests: []string{"tests::a"}},
+	}
+
+	best, ok := BestEffort(results)
+	if !ok {
+		t.Fatal("BestEffort() should find a result")
+	}
+	if best.Index != 1 {
+		t.Errorf("BestEffort().Index = %d, want 1", best.Index)
+	}
+}
+
+func TestBestEffortPrefersResultThatRanAtAll(t *testing.T) {
+	results := []Result{
+		{Index: 0, Reason: "cargo check failed: ..."},
+		{Index: 1, FailedTests: []string{"tests::a"}},
+	}
+
+	best, ok := BestEffort(results)
+	if !ok {
+		t.Fatal("BestEffort() should find a result")
+	}
+	if best.Index != 1 {
+		t.Errorf("BestEffort().Index = %d, want 1 (it at least compiled and ran)", best.Index)
+	}
+}
+
+func TestBestEffortReturnsFalseForEmpty(t *testing.T) {
+	if _, ok := BestEffort(nil); ok {
+		t.Error("BestEffort() should return false for no results")
+	}
+}
+
+func TestStripFailingTestsRemovesNamedTest(t *testing.T) {
+	code := `#[test]
+fn it_passes() {
+    assert_eq!(1, 1);
+}
+
+#[test]
+fn it_fails() {
+    assert_eq!(1, 2);
+}`
+
+	stripped := StripFailingTests(code, []string{"tests::it_fails"})
+	if !strings.Contains(stripped, "fn it_passes") {
+		t.Error("StripFailingTests() should keep the passing test")
+	}
+	if strings.Contains(stripped, "fn it_fails") {
+		t.Error("StripFailingTests() should remove the failing test")
+	}
+}
+
+func TestStripFailingTestsNoopWithoutFailures(t *testing.T) {
+	code := `#[test]
+fn it_passes() {
+    assert_eq!(1, 1);
+}`
+	if got := StripFailingTests(code, nil); got != code {
+		t.Errorf("StripFailingTests() = %q, want unchanged input", got)
+	}
+}
@@ -0,0 +1,613 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package candidate evaluates several generated test candidates for the
+// same target file in isolation, so --candidates can pick the one that
+// actually improves coverage the most instead of just the first one that
+// happens to compile.
+package candidate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/lint"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/mutants"
+)
+
+// Result is one candidate's generated test and validation outcome.
+type Result struct {
+	// Index is the candidate's 0-based position among the N requested.
+	Index int `json:"index"`
+	// Test is the candidate's generated test code.
+	Test string `json:"test"`
+	// Valid reports whether the candidate passed validation and had its
+	// coverage measured.
+	Valid bool `json:"valid"`
+	// Reason explains why an invalid candidate failed, if !Valid.
+	Reason string `json:"reason,omitempty"`
+	// FailedTests are the names of the individual tests that failed, if
+	// ValidateCommand ran under cargo-nextest and at least one did; nil
+	// for a validation failure reported by any other runner (a compile
+	// error, or a plain `cargo test`/`make ut` failure).
+	FailedTests []string `json:"failedTests,omitempty"`
+	// RemovedTests are the names of tests BisectFailingTests stripped from
+	// the candidate to make it pass, if it was set and had to remove any.
+	RemovedTests []string `json:"removedTests,omitempty"`
+	// CoveragePercent is the target file's line coverage with the
+	// candidate applied, if Valid.
+	CoveragePercent float64 `json:"coveragePercent,omitempty"`
+	// Delta is CoveragePercent minus the baseline coverage passed to
+	// Evaluate, if Valid.
+	Delta float64 `json:"delta,omitempty"`
+	// MutationScore is the fraction of mutants MutantsCommand introduced
+	// into file that the candidate's tests caught, if MutantsCommand was
+	// set and ran.
+	MutationScore float64 `json:"mutationScore,omitempty"`
+}
+
+// Evaluator validates candidate test code for a target file in disposable
+// git worktrees, so validating one candidate can never corrupt another's
+// run or the caller's own working tree.
+type Evaluator struct {
+	// RepoRoot is the workspace root to branch worktrees from.
+	RepoRoot string
+	// CheckCommand, if set, is run from the worktree root before
+	// ValidateCommand; a non-zero exit fails the candidate. Used as a fast
+	// `cargo check --tests` pre-pass so a non-compiling generation is
+	// rejected in seconds instead of waiting on the full test run.
+	CheckCommand []string
+	// PreValidateCommand, if set, is run from the worktree root after
+	// CheckCommand and before ValidateCommand; a non-zero exit fails the
+	// candidate. Unlike CheckCommand, it is entirely caller-configured
+	// (e.g. a project-specific integration smoke test), for validation
+	// steps a compile check alone can't cover.
+	PreValidateCommand []string
+	// ValidateCommand is run from the worktree root; a non-zero exit
+	// fails the candidate (e.g. backend.Backend.ValidateCommand(), or a
+	// caller-configured replacement for it).
+	ValidateCommand []string
+	// SmokeTestCommand, if set, is run from the worktree root after
+	// ValidateCommand (and any BisectFailingTests retries) succeed, but
+	// before ClippyCommand, as a second validation tier for critical paths
+	// (e.g. the FUSE server) where passing unit tests alone isn't enough
+	// confidence that generated tests didn't break integration behavior. A
+	// non-zero exit fails the candidate.
+	SmokeTestCommand []string
+	// ClippyCommand, if set, is run from the worktree root after
+	// ValidateCommand succeeds; a non-zero exit fails the candidate. Used
+	// to gate generated tests on the same `cargo clippy -- -D warnings`
+	// lints CI enforces.
+	ClippyCommand []string
+	// CoverageCommand is run from the worktree root after ValidateCommand
+	// (and ClippyCommand, if set) succeeds, and must print a `cargo
+	// llvm-cov --json` export to stdout.
+	CoverageCommand []string
+	// SkipFormat disables the rustfmt pass normally run on the appended
+	// file before ValidateCommand, letting inconsistently indented
+	// generated tests through un-normalized.
+	SkipFormat bool
+	// MiriCommand, if set, is additionally run from the worktree root
+	// after ValidateCommand succeeds, but only when the target file
+	// contains an `unsafe` block, to catch UB in generated tests
+	// exercising unsafe code paths that a normal test run wouldn't.
+	MiriCommand []string
+	// CrossCheckCommands, if set, are additional `cargo check --tests
+	// --target <triple>` commands run from the worktree root after
+	// ClippyCommand succeeds, one per configured cross-compilation target,
+	// so a candidate that only compiles for the host doesn't silently
+	// break a musl or aarch64 build.
+	CrossCheckCommands [][]string
+	// ToolchainCheckCommands, if set, are additional `cargo +<toolchain>
+	// check --tests` commands run from the worktree root after
+	// CrossCheckCommands succeed, one per toolchain to validate against
+	// (typically the repo's MSRV and stable), so a candidate that only
+	// compiles on the toolchain running the rest of Evaluate doesn't
+	// silently use a newer language feature than the project supports.
+	ToolchainCheckCommands [][]string
+	// MinDelta is the minimum coverage-percentage-point improvement over
+	// baseline a candidate must reach to be accepted; a passing candidate
+	// that doesn't clear it is marked invalid instead, since a compiling
+	// but useless test isn't worth keeping.
+	MinDelta float64
+	// Denylist is additional regular expressions, beyond lint.Check's
+	// built-in rules, that reject a candidate outright before it is ever
+	// compiled or run.
+	Denylist []string
+	// MinAssertionDensity is the minimum lint.AssertionDensity a candidate
+	// must reach to be accepted; a candidate below it is rejected outright,
+	// before it is ever compiled or run, since a test with no real
+	// assertions isn't worth a validation cycle regardless of whether it
+	// compiles.
+	MinAssertionDensity float64
+	// MutantsCommand, if set, is run from the worktree root after
+	// ValidateCommand (and ClippyCommand/MiriCommand, if set) succeed, to
+	// measure how many mutants introduced into file the candidate's tests
+	// catch. A non-zero exit (cargo-mutants' own signal that mutants
+	// survived) is not itself a failure; only MinMutationScore is.
+	MutantsCommand []string
+	// MinMutationScore is the minimum mutants.Score.Rate() a candidate must
+	// reach to be accepted, if MutantsCommand is set; a candidate below it
+	// is rejected, since tests that compile, pass, and improve coverage but
+	// don't actually constrain behavior aren't worth keeping.
+	MinMutationScore float64
+	// LogDir, if set, is where Evaluate writes attempt-<index+1>.log (every
+	// command's combined output, in the order run) and attempt-<index+1>.diff
+	// (the unified diff of testCode's insertion into file), so a failed run
+	// can be debugged later without rerunning it.
+	LogDir string
+	// TargetDir, if set, is used as CARGO_TARGET_DIR for every command
+	// instead of a fresh directory under each disposable worktree, so
+	// repeated Evaluate calls against the same RepoRoot (e.g. retry
+	// attempts) reuse previously compiled build artifacts rather than
+	// recompiling the crate from scratch every time. Sharing it across
+	// concurrent Evaluate calls is safe: cargo locks the target directory
+	// itself.
+	TargetDir string
+	// Sccache, if set, points RUSTC_WRAPPER at sccache for every command,
+	// so even without TargetDir set, compilation artifacts are cached and
+	// reused across worktrees.
+	Sccache bool
+	// Incremental, if set, enables incremental compilation
+	// (CARGO_INCREMENTAL=1), trading a larger TargetDir for faster
+	// rebuilds across retry attempts.
+	Incremental bool
+	// CommandTimeout, if nonzero, is applied to every command Evaluate
+	// runs; a command still running when it elapses is killed and treated
+	// the same as a non-zero exit, so a hung validation or pre-validate
+	// command (e.g. a smoke test that deadlocks) fails the candidate
+	// instead of the run.
+	CommandTimeout time.Duration
+	// BisectFailingTests, if set, retries a candidate that fails
+	// ValidateCommand with cargo-nextest-identified failing test(s) removed,
+	// up to maxBisectAttempts times, so a candidate with e.g. 8 good tests
+	// and 2 flaky or wrong ones is salvaged instead of discarded outright.
+	// The removed tests are recorded in Result.RemovedTests.
+	BisectFailingTests bool
+}
+
+// Evaluate rejects testCode outright if lint.Check finds a denylisted
+// pattern in it, or if its lint.AssertionDensity falls below
+// MinAssertionDensity; otherwise it appends testCode to file in a disposable
+// worktree, formats it with rustfmt, runs CheckCommand, PreValidateCommand,
+// ValidateCommand, (if set) SmokeTestCommand, ClippyCommand,
+// CrossCheckCommands, ToolchainCheckCommands, (if file contains an `unsafe`
+// block) MiriCommand, and (if set) MutantsCommand, and — if all pass —
+// CoverageCommand to measure
+// file's resulting line coverage against baseline. If ValidateCommand fails
+// with individually identified failing test(s) and BisectFailingTests is
+// set, it retries with those tests stripped instead of failing outright. If
+// LogDir is set, every command's output and the inserted test's diff are
+// persisted there regardless of outcome.
+func (e Evaluator) Evaluate(index int, file, testCode string, baseline float64) Result {
+	result := Result{Index: index, Test: testCode}
+
+	if violations := lint.Check(testCode, e.Denylist); len(violations) > 0 {
+		names := make([]string, len(violations))
+		for i, v := range violations {
+			names[i] = v.String()
+		}
+		result.Reason = "denylisted pattern(s) in generated test: " + strings.Join(names, ", ")
+		return result
+	}
+
+	if density := lint.AssertionDensity(testCode); density < e.MinAssertionDensity {
+		result.Reason = fmt.Sprintf("assertion density %.2f is below --min-assertion-density %.2f: test looks tautological", density, e.MinAssertionDensity)
+		return result
+	}
+
+	var log strings.Builder
+	defer func() { e.writeLog(index, log.String()) }()
+
+	worktree, cleanup, err := e.addWorktree()
+	if err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+	defer cleanup()
+
+	target := filepath.Join(worktree, file)
+	original, err := os.ReadFile(target)
+	if err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+	if err := appendToFile(target, testCode); err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+	e.writeDiff(index, worktree)
+
+	if !e.SkipFormat {
+		out, err := e.run(worktree, []string{"rustfmt", target})
+		log.WriteString(logEntry([]string{"rustfmt", target}, out))
+		if err != nil {
+			result.Reason = "rustfmt failed: " + err.Error()
+			return result
+		}
+	}
+
+	if len(e.CheckCommand) > 0 {
+		out, err := e.run(worktree, e.CheckCommand)
+		log.WriteString(logEntry(e.CheckCommand, out))
+		if err != nil {
+			result.Reason = "cargo check failed: " + err.Error()
+			return result
+		}
+	}
+
+	if len(e.PreValidateCommand) > 0 {
+		out, err := e.run(worktree, e.PreValidateCommand)
+		log.WriteString(logEntry(e.PreValidateCommand, out))
+		if err != nil {
+			result.Reason = "pre-validate command failed: " + err.Error()
+			return result
+		}
+	}
+
+	validateOut, err := e.run(worktree, e.ValidateCommand)
+	log.WriteString(logEntry(e.ValidateCommand, validateOut))
+	if err != nil {
+		names := failedTests(validateOut)
+		if len(names) == 0 {
+			result.Reason = "validation failed: " + err.Error()
+			return result
+		}
+		if !e.BisectFailingTests {
+			result.FailedTests = names
+			result.Reason = "validation failed, failing test(s): " + strings.Join(names, ", ")
+			return result
+		}
+
+		strippedCode, removed, bisectErr := e.bisectFailingTests(worktree, target, original, testCode, names, &log)
+		if bisectErr != nil {
+			result.FailedTests = removed
+			result.Reason = bisectErr.Error()
+			return result
+		}
+		testCode = strippedCode
+		result.Test = testCode
+		result.RemovedTests = removed
+	}
+
+	if len(e.SmokeTestCommand) > 0 {
+		out, err := e.run(worktree, e.SmokeTestCommand)
+		log.WriteString(logEntry(e.SmokeTestCommand, out))
+		if err != nil {
+			result.Reason = "smoke test failed: " + err.Error()
+			return result
+		}
+	}
+
+	if len(e.ClippyCommand) > 0 {
+		out, err := e.run(worktree, e.ClippyCommand)
+		log.WriteString(logEntry(e.ClippyCommand, out))
+		if err != nil {
+			result.Reason = "clippy failed: " + err.Error()
+			return result
+		}
+	}
+
+	for _, command := range e.CrossCheckCommands {
+		out, err := e.run(worktree, command)
+		log.WriteString(logEntry(command, out))
+		if err != nil {
+			result.Reason = fmt.Sprintf("cross-target check failed (%s): %s", strings.Join(command, " "), err.Error())
+			return result
+		}
+	}
+
+	for _, command := range e.ToolchainCheckCommands {
+		out, err := e.run(worktree, command)
+		log.WriteString(logEntry(command, out))
+		if err != nil {
+			result.Reason = fmt.Sprintf("toolchain check failed (%s): %s", strings.Join(command, " "), err.Error())
+			return result
+		}
+	}
+
+	if len(e.MiriCommand) > 0 && fileContainsUnsafe(target) {
+		out, err := e.run(worktree, e.MiriCommand)
+		log.WriteString(logEntry(e.MiriCommand, out))
+		if err != nil {
+			result.Reason = "miri failed: " + err.Error()
+			return result
+		}
+	}
+
+	if len(e.MutantsCommand) > 0 {
+		mutantsOut, _ := e.run(worktree, e.MutantsCommand)
+		log.WriteString(logEntry(e.MutantsCommand, mutantsOut))
+
+		score, err := readMutationScore(worktree)
+		if err != nil {
+			result.Reason = "cargo-mutants failed: " + err.Error()
+			return result
+		}
+		result.MutationScore = score.Rate()
+		if result.MutationScore < e.MinMutationScore {
+			result.Reason = fmt.Sprintf("mutation score %.2f is below --min-mutation-score %.2f", result.MutationScore, e.MinMutationScore)
+			return result
+		}
+	}
+
+	out, err := e.output(worktree, e.CoverageCommand)
+	log.WriteString(logEntry(e.CoverageCommand, out))
+	if err != nil {
+		result.Reason = "coverage measurement failed: " + err.Error()
+		return result
+	}
+
+	report, err := coverage.Parse(strings.NewReader(out))
+	if err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+
+	for _, f := range report.Files {
+		if f.Path == file {
+			result.CoveragePercent = f.Percent()
+			break
+		}
+	}
+
+	result.Delta = result.CoveragePercent - baseline
+	if result.Delta < e.MinDelta {
+		result.Reason = fmt.Sprintf("coverage improved by only %.2f%%, below --min-delta %.2f%%", result.Delta, e.MinDelta)
+		return result
+	}
+
+	result.Valid = true
+	return result
+}
+
+// Best returns the valid result with the highest coverage Delta, and
+// whether any result was valid at all.
+func Best(results []Result) (Result, bool) {
+	var best Result
+	found := false
+	for _, r := range results {
+		if !r.Valid {
+			continue
+		}
+		if !found || r.Delta > best.Delta {
+			best = r
+			found = true
+		}
+	}
+	return best, found
+}
+
+func (e Evaluator) addWorktree() (string, func(), error) {
+	return Worktree(e.RepoRoot)
+}
+
+// Worktree checks out a disposable, detached git worktree from repoRoot's
+// HEAD and returns its path and a cleanup func that removes it, so a caller
+// can try a change in isolation before ever touching the real checkout.
+func Worktree(repoRoot string) (string, func(), error) {
+	parent, err := os.MkdirTemp("", "coverage-tool-candidate-")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "create temp dir for worktree")
+	}
+
+	worktree := filepath.Join(parent, "wt")
+	if _, err := gitCmd(repoRoot, "worktree", "add", "--detach", "--force", worktree, "HEAD"); err != nil {
+		os.RemoveAll(parent)
+		return "", nil, errors.Wrap(err, "add worktree")
+	}
+
+	cleanup := func() {
+		_, _ = gitCmd(repoRoot, "worktree", "remove", "--force", worktree)
+		os.RemoveAll(parent)
+	}
+	return worktree, cleanup, nil
+}
+
+// run runs command from dir and returns its combined stdout/stderr, whether
+// or not it succeeded, so a caller can persist it via LogDir either way.
+func (e Evaluator) run(dir string, command []string) (string, error) {
+	ctx, cancel := e.commandContext()
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Env = e.cargoEnv(dir)
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return string(out), errors.Errorf("%v: timed out after %s", command, e.CommandTimeout)
+	}
+	if err != nil {
+		return string(out), errors.Wrapf(err, "%v: %s", command, out)
+	}
+	return string(out), nil
+}
+
+func (e Evaluator) output(dir string, command []string) (string, error) {
+	ctx, cancel := e.commandContext()
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Env = e.cargoEnv(dir)
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", errors.Errorf("%v: timed out after %s", command, e.CommandTimeout)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "%v", command)
+	}
+	return string(out), nil
+}
+
+// commandContext returns a context bounded by CommandTimeout, if set, or
+// context.Background() otherwise, along with its cancel func.
+func (e Evaluator) commandContext() (context.Context, context.CancelFunc) {
+	if e.CommandTimeout <= 0 {
+		return context.Background(), func() {}
+	}
+	return context.WithTimeout(context.Background(), e.CommandTimeout)
+}
+
+// cargoEnv points CARGO_TARGET_DIR at TargetDir, if set, so retry attempts
+// against the same RepoRoot can reuse build artifacts; otherwise it falls
+// back to a subdirectory of dir, so concurrent Evaluate calls on separate
+// worktrees never share a build cache and lock each other out of it. It
+// also applies Sccache and Incremental, if set.
+func (e Evaluator) cargoEnv(dir string) []string {
+	targetDir := e.TargetDir
+	if targetDir == "" {
+		targetDir = filepath.Join(dir, "target")
+	}
+	env := append(os.Environ(), "CARGO_TARGET_DIR="+targetDir)
+	if e.Sccache {
+		env = append(env, "RUSTC_WRAPPER=sccache")
+	}
+	if e.Incremental {
+		env = append(env, "CARGO_INCREMENTAL=1")
+	}
+	return env
+}
+
+// logEntry renders one command's output as a "$ command\n<output>\n"
+// section for LogDir's per-attempt log.
+func logEntry(command []string, out string) string {
+	return fmt.Sprintf("$ %s\n%s\n", strings.Join(command, " "), out)
+}
+
+// writeLog writes log to LogDir/attempt-<index+1>.log, if LogDir is set,
+// best-effort: a failure to persist debug output shouldn't fail the
+// candidate it's describing.
+func (e Evaluator) writeLog(index int, log string) {
+	if e.LogDir == "" {
+		return
+	}
+	if err := os.MkdirAll(e.LogDir, 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(e.LogDir, fmt.Sprintf("attempt-%d.log", index+1)), []byte(log), 0o644)
+}
+
+// writeDiff writes the unified diff of testCode's insertion into file,
+// captured via `git diff` in worktree, to LogDir/attempt-<index+1>.diff, if
+// LogDir is set.
+func (e Evaluator) writeDiff(index int, worktree string) {
+	if e.LogDir == "" {
+		return
+	}
+	diff, err := e.git(worktree, "diff")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(e.LogDir, 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(e.LogDir, fmt.Sprintf("attempt-%d.diff", index+1)), []byte(diff), 0o644)
+}
+
+// readMutationScore reads and parses the mutants.out/outcomes.json cargo
+// mutants writes under worktree.
+func readMutationScore(worktree string) (mutants.Score, error) {
+	f, err := os.Open(filepath.Join(worktree, "mutants.out", "outcomes.json"))
+	if err != nil {
+		return mutants.Score{}, errors.Wrap(err, "open mutants.out/outcomes.json")
+	}
+	defer f.Close()
+
+	return mutants.Parse(f)
+}
+
+func (e Evaluator) git(dir string, args ...string) (string, error) {
+	return gitCmd(dir, args...)
+}
+
+func gitCmd(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), errors.Wrapf(err, "git %v: %s", args, out)
+	}
+	return string(out), nil
+}
+
+// fileContainsUnsafe reports whether path's contents contain an `unsafe`
+// block, a plain substring check that tolerates false positives (e.g. a
+// comment mentioning "unsafe") in exchange for not needing a Rust parser
+// here; the cost of an unnecessary Miri run is far lower than missing UB in
+// one that should have run.
+func fileContainsUnsafe(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), "unsafe")
+}
+
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "open %s for appending", path)
+	}
+	defer f.Close()
+
+	_, err = f.WriteString("\n" + content + "\n")
+	return errors.Wrapf(err, "append test code to %s", path)
+}
+
+// rewriteTestCode overwrites path with original followed by testCode,
+// replacing whatever was previously appended to it; unlike appendToFile it
+// is not append-only, so a bisection retry can reconstruct the file from
+// scratch instead of stacking a stripped attempt on top of the last one.
+func rewriteTestCode(path string, original []byte, testCode string) error {
+	content := append(append([]byte{}, original...), []byte("\n"+testCode+"\n")...)
+	return errors.Wrapf(os.WriteFile(path, content, 0o644), "rewrite %s", path)
+}
+
+// maxBisectAttempts bounds how many times bisectFailingTests will strip
+// newly identified failing tests and retry, so a candidate whose tests keep
+// failing for unrelated reasons (e.g. a shared, broken fixture) doesn't loop
+// forever chasing them one at a time.
+const maxBisectAttempts = 5
+
+// bisectFailingTests retries ValidateCommand with failing (and any
+// subsequently identified failing) tests stripped from testCode, up to
+// maxBisectAttempts times, returning the stripped code and the names of every
+// test it removed once ValidateCommand passes. It gives up, returning an
+// error, if a retry fails without identifying which test(s) failed (nothing
+// left to bisect on) or maxBisectAttempts is exhausted.
+func (e Evaluator) bisectFailingTests(worktree, target string, original []byte, testCode string, failing []string, log *strings.Builder) (string, []string, error) {
+	var removed []string
+	code := testCode
+
+	for attempt := 0; attempt < maxBisectAttempts; attempt++ {
+		code = StripFailingTests(code, failing)
+		removed = append(removed, failing...)
+
+		if err := rewriteTestCode(target, original, code); err != nil {
+			return "", removed, err
+		}
+
+		out, err := e.run(worktree, e.ValidateCommand)
+		log.WriteString(logEntry(e.ValidateCommand, out))
+		if err == nil {
+			return code, removed, nil
+		}
+
+		failing = failedTests(out)
+		if len(failing) == 0 {
+			return "", removed, errors.Errorf("bisection failed: validation failed without identifying which test(s) failed: %s", err.Error())
+		}
+	}
+
+	return "", removed, errors.Errorf("bisection gave up after %d attempt(s), still failing: %s", maxBisectAttempts, strings.Join(failing, ", "))
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package candidate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBestPicksHighestDelta(t *testing.T) {
+	results := []Result{
+		{Index: 0, Valid: true, Delta: 5},
+		{Index: 1, Valid: true, Delta: 12},
+		{Index: 2, Valid: false, Delta: 99},
+	}
+
+	best, ok := Best(results)
+	if !ok {
+		t.Fatal("Best() should find a valid candidate")
+	}
+	if best.Index != 1 {
+		t.Errorf("Best().Index = %d, want 1", best.Index)
+	}
+}
+
+func TestBestReturnsFalseWhenNoneValid(t *testing.T) {
+	results := []Result{{Index: 0, Valid: false}, {Index: 1, Valid: false}}
+
+	if _, ok := Best(results); ok {
+		t.Error("Best() should return false when no candidate is valid")
+	}
+}
+
+func TestFileContainsUnsafeDetectsUnsafeBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "device.rs")
+	if err := os.WriteFile(path, []byte("fn f() {\n    unsafe { g() }\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !fileContainsUnsafe(path) {
+		t.Error("fileContainsUnsafe() = false, want true for a file with an unsafe block")
+	}
+}
+
+func TestFileContainsUnsafeFalseForSafeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "device.rs")
+	if err := os.WriteFile(path, []byte("fn f() {\n    g()\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if fileContainsUnsafe(path) {
+		t.Error("fileContainsUnsafe() = true, want false for a file with no unsafe block")
+	}
+}
+
+func TestFileContainsUnsafeFalseWhenFileMissing(t *testing.T) {
+	if fileContainsUnsafe(filepath.Join(t.TempDir(), "missing.rs")) {
+		t.Error("fileContainsUnsafe() = true, want false when the file can't be read")
+	}
+}
+
+func TestLogEntryRendersCommandAndOutput(t *testing.T) {
+	entry := logEntry([]string{"cargo", "test"}, "ok\n")
+	want := "$ cargo test\nok\n\n"
+	if entry != want {
+		t.Errorf("logEntry() = %q, want %q", entry, want)
+	}
+}
+
+func TestWriteLogWritesAttemptFile(t *testing.T) {
+	dir := t.TempDir()
+	e := Evaluator{LogDir: dir}
+	e.writeLog(2, "log contents")
+
+	data, err := os.ReadFile(filepath.Join(dir, "attempt-3.log"))
+	if err != nil {
+		t.Fatalf("read attempt-3.log: %v", err)
+	}
+	if string(data) != "log contents" {
+		t.Errorf("attempt-3.log = %q, want %q", data, "log contents")
+	}
+}
+
+func TestWriteLogNoopWithoutLogDir(t *testing.T) {
+	dir := t.TempDir()
+	e := Evaluator{}
+	e.writeLog(0, "log contents")
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("writeLog() with no LogDir wrote %d file(s), want 0", len(entries))
+	}
+}
+
+func TestReadMutationScoreParsesOutcomesFile(t *testing.T) {
+	worktree := t.TempDir()
+	outDir := filepath.Join(worktree, "mutants.out")
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outcomes := `{"outcomes": [{"summary": "CaughtMutant"}, {"summary": "MissedMutant"}]}`
+	if err := os.WriteFile(filepath.Join(outDir, "outcomes.json"), []byte(outcomes), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	score, err := readMutationScore(worktree)
+	if err != nil {
+		t.Fatalf("readMutationScore() error = %v", err)
+	}
+	if got, want := score.Rate(), 0.5; got != want {
+		t.Errorf("readMutationScore().Rate() = %v, want %v", got, want)
+	}
+}
+
+func TestReadMutationScoreErrorsWithoutOutcomesFile(t *testing.T) {
+	if _, err := readMutationScore(t.TempDir()); err == nil {
+		t.Error("readMutationScore() should error when mutants.out/outcomes.json doesn't exist")
+	}
+}
+
+func TestCommandContextNoTimeoutUsesBackground(t *testing.T) {
+	e := Evaluator{}
+	ctx, cancel := e.commandContext()
+	defer cancel()
+	if ctx.Done() != nil {
+		t.Error("commandContext() with no CommandTimeout should not carry a deadline")
+	}
+}
+
+func TestCommandContextAppliesTimeout(t *testing.T) {
+	e := Evaluator{CommandTimeout: time.Millisecond}
+	ctx, cancel := e.commandContext()
+	defer cancel()
+
+	<-ctx.Done()
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("commandContext().Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+}
+
+func TestRewriteTestCodeReplacesPriorContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "device.rs")
+	original := []byte("fn f() {}\n")
+	if err := os.WriteFile(path, append(append([]byte{}, original...), []byte("\nstale test code\n")...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rewriteTestCode(path, original, "fresh test code"); err != nil {
+		t.Fatalf("rewriteTestCode() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "fn f() {}\n\nfresh test code\n"
+	if string(got) != want {
+		t.Errorf("rewriteTestCode() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package candidate
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// nextestEvent is the subset of cargo-nextest's `--message-format
+// libtest-json-plus` per-line event stream this package cares about.
+type nextestEvent struct {
+	Type  string `json:"type"`
+	Event string `json:"event"`
+	Name  string `json:"name"`
+}
+
+// failedTests extracts the names of tests cargo-nextest reported as failed
+// from output, one JSON event per line, or nil if output isn't
+// libtest-json-plus (e.g. it came from a plain `cargo test`/`make ut` run).
+func failedTests(output string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var event nextestEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			continue
+		}
+		if event.Type == "test" && event.Event == "failed" {
+			names = append(names, event.Name)
+		}
+	}
+	return names
+}
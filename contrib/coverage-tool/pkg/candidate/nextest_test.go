@@ -0,0 +1,31 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package candidate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFailedTestsExtractsFailedEventNames(t *testing.T) {
+	output := `{"type":"suite","event":"started","test_count":2}
+{"type":"test","event":"started","name":"tests::test_a"}
+{"type":"test","event":"ok","name":"tests::test_a"}
+{"type":"test","event":"started","name":"tests::test_b"}
+{"type":"test","event":"failed","name":"tests::test_b"}
+{"type":"suite","event":"failed","passed":1,"failed":1}`
+
+	got := failedTests(output)
+	want := []string{"tests::test_b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("failedTests(...) = %v, want %v", got, want)
+	}
+}
+
+func TestFailedTestsNilForNonJSONOutput(t *testing.T) {
+	if got := failedTests("error[E0425]: cannot find value `x` in this scope"); got != nil {
+		t.Errorf("failedTests(...) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package checkrun creates GitHub check runs, so gating a pull request on
+// coverage thresholds surfaces a detailed, re-runnable status instead of
+// only a workflow step's exit code.
+package checkrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Conclusion is a GitHub check run conclusion.
+type Conclusion string
+
+const (
+	Success Conclusion = "success"
+	Neutral Conclusion = "neutral"
+	Failure Conclusion = "failure"
+)
+
+// AnnotationLevel is a GitHub check run annotation's severity.
+type AnnotationLevel string
+
+const (
+	LevelNotice  AnnotationLevel = "notice"
+	LevelWarning AnnotationLevel = "warning"
+	LevelFailure AnnotationLevel = "failure"
+)
+
+// Annotation is a single per-line note attached to a check run's output,
+// so reviewers see it inline on the file in the PR diff.
+type Annotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	Level     AnnotationLevel
+	Message   string
+}
+
+// maxAnnotations is the most annotations the GitHub Checks API accepts in
+// a single create-check-run request; Create silently truncates to it
+// rather than failing the whole check over an API limit unrelated to
+// whether the check itself passed.
+const maxAnnotations = 50
+
+// Output is the detailed report attached to a check run.
+type Output struct {
+	Title       string
+	Summary     string
+	Annotations []Annotation
+}
+
+// annotationPayload is the GitHub Checks API's wire shape for a single
+// output annotation.
+type annotationPayload struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Message         string `json:"message"`
+}
+
+// outputPayload is the GitHub Checks API's wire shape for a check run's
+// output.
+type outputPayload struct {
+	Title       string              `json:"title"`
+	Summary     string              `json:"summary"`
+	Annotations []annotationPayload `json:"annotations,omitempty"`
+}
+
+// checkRunPayload is the GitHub Checks API's wire shape for creating a
+// completed check run.
+type checkRunPayload struct {
+	Name       string        `json:"name"`
+	HeadSHA    string        `json:"head_sha"`
+	Status     string        `json:"status"`
+	Conclusion string        `json:"conclusion"`
+	Output     outputPayload `json:"output"`
+}
+
+// Create posts a completed check run for sha in owner/repo, so the
+// coverage gate's result appears alongside other CI checks with a
+// title and summary explaining the outcome, rather than only a
+// pass/fail exit code.
+func Create(owner, repo, sha, name string, conclusion Conclusion, output Output) error {
+	annotations := output.Annotations
+	if len(annotations) > maxAnnotations {
+		annotations = annotations[:maxAnnotations]
+	}
+	annotationPayloads := make([]annotationPayload, len(annotations))
+	for i, a := range annotations {
+		annotationPayloads[i] = annotationPayload{
+			Path:            a.Path,
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			AnnotationLevel: string(a.Level),
+			Message:         a.Message,
+		}
+	}
+
+	payload, err := json.Marshal(checkRunPayload{
+		Name:       name,
+		HeadSHA:    sha,
+		Status:     "completed",
+		Conclusion: string(conclusion),
+		Output: outputPayload{
+			Title:       output.Title,
+			Summary:     output.Summary,
+			Annotations: annotationPayloads,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal check run payload")
+	}
+
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/check-runs", owner, repo)
+	req, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "create check run")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return errors.Errorf("GitHub API returned status %d for %s", resp.StatusCode, apiURL)
+	}
+
+	return nil
+}
+
+// Evaluate maps an overall coverage percentage to a conclusion: below
+// minPercent fails the check, below warnPercent leaves it neutral (so it
+// doesn't block merges but is visible), and anything else succeeds.
+func Evaluate(percent, minPercent, warnPercent float64) Conclusion {
+	switch {
+	case percent < minPercent:
+		return Failure
+	case percent < warnPercent:
+		return Neutral
+	default:
+		return Success
+	}
+}
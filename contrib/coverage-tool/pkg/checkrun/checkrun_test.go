@@ -0,0 +1,27 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package checkrun
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent float64
+		want    Conclusion
+	}{
+		{"below minimum fails", 40, Failure},
+		{"below warn is neutral", 65, Neutral},
+		{"meets warn succeeds", 80, Success},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Evaluate(tt.percent, 50, 75); got != tt.want {
+				t.Errorf("Evaluate(%v) = %v, want %v", tt.percent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chunk splits a Rust source file into its individual function
+// definitions, so cmd/generate.go can request tests for a large file in
+// several smaller completions instead of risking a truncated response on
+// one oversized prompt.
+package chunk
+
+import (
+	"regexp"
+	"strings"
+)
+
+// fnSignature matches a Rust function signature line, allowing
+// pub/pub(crate)/async/unsafe/const modifiers before fn.
+var fnSignature = regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:unsafe\s+)?(?:const\s+)?fn\s+(\w+)`)
+
+// Function is one function definition's full source text, from its
+// signature to its closing brace.
+type Function struct {
+	Name string
+	Body string
+}
+
+// Split returns every function definition found in source, in the order
+// they appear, using brace counting (not a real Rust parser) to find each
+// one's extent. Braces inside string or comment literals are not special
+// cased; this is a best-effort split, not a syntactic guarantee.
+func Split(source string) []Function {
+	lines := strings.Split(source, "\n")
+
+	var functions []Function
+	for i := 0; i < len(lines); i++ {
+		m := fnSignature.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+
+		end, ok := matchBrace(lines, i)
+		if !ok {
+			continue
+		}
+
+		functions = append(functions, Function{Name: m[1], Body: strings.Join(lines[i:end+1], "\n")})
+		i = end
+	}
+
+	return functions
+}
+
+// Group splits functions into batches of at most size, preserving order,
+// so a caller can send one completion request per batch instead of one
+// per function. size <= 0 puts every function in a single group.
+func Group(functions []Function, size int) [][]Function {
+	if len(functions) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		size = len(functions)
+	}
+
+	var groups [][]Function
+	for i := 0; i < len(functions); i += size {
+		end := i + size
+		if end > len(functions) {
+			end = len(functions)
+		}
+		groups = append(groups, functions[i:end])
+	}
+	return groups
+}
+
+// matchBrace returns the index of the line containing the closing brace
+// that balances the first "{" found at or after start, or false if the
+// braces never balance before the file ends.
+func matchBrace(lines []string, start int) (int, bool) {
+	depth := 0
+	seenOpen := false
+	for i := start; i < len(lines); i++ {
+		for _, r := range lines[i] {
+			switch r {
+			case '{':
+				depth++
+				seenOpen = true
+			case '}':
+				depth--
+			}
+		}
+		if seenOpen && depth == 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
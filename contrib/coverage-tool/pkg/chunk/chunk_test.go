@@ -0,0 +1,68 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package chunk
+
+import "testing"
+
+const source = `use std::fmt;
+
+pub fn add(a: i32, b: i32) -> i32 {
+    a + b
+}
+
+fn helper() {
+    if true {
+        println!("nested braces");
+    }
+}
+
+pub async fn fetch() -> i32 {
+    42
+}
+`
+
+func TestSplitFindsEveryFunction(t *testing.T) {
+	functions := Split(source)
+	if len(functions) != 3 {
+		t.Fatalf("Split() returned %d functions, want 3", len(functions))
+	}
+
+	names := []string{functions[0].Name, functions[1].Name, functions[2].Name}
+	want := []string{"add", "helper", "fetch"}
+	for i, n := range names {
+		if n != want[i] {
+			t.Errorf("functions[%d].Name = %q, want %q", i, n, want[i])
+		}
+	}
+}
+
+func TestSplitCapturesNestedBraces(t *testing.T) {
+	functions := Split(source)
+	if functions[1].Body != "fn helper() {\n    if true {\n        println!(\"nested braces\");\n    }\n}" {
+		t.Errorf("helper body = %q, want the full nested block", functions[1].Body)
+	}
+}
+
+func TestGroupBatchesInOrder(t *testing.T) {
+	functions := Split(source)
+	groups := Group(functions, 2)
+	if len(groups) != 2 {
+		t.Fatalf("Group() returned %d groups, want 2", len(groups))
+	}
+	if len(groups[0]) != 2 || len(groups[1]) != 1 {
+		t.Errorf("group sizes = %d, %d, want 2, 1", len(groups[0]), len(groups[1]))
+	}
+	if groups[1][0].Name != "fetch" {
+		t.Errorf("last group's function = %q, want fetch", groups[1][0].Name)
+	}
+}
+
+func TestGroupZeroSizeIsOneGroup(t *testing.T) {
+	functions := Split(source)
+	groups := Group(functions, 0)
+	if len(groups) != 1 || len(groups[0]) != len(functions) {
+		t.Errorf("Group(functions, 0) = %v, want a single group with all functions", groups)
+	}
+}
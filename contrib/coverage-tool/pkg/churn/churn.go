@@ -0,0 +1,45 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package churn counts recent commit activity per file, on the premise
+// that frequently changed but poorly covered files are the riskiest ones
+// to leave untested.
+package churn
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CountsSince returns the number of commits that touched each file under
+// repoRoot in the last `window`, keyed by path relative to repoRoot.
+func CountsSince(repoRoot string, window time.Duration) (map[string]int, error) {
+	since := fmt.Sprintf("--since=%s", time.Now().Add(-window).Format("2006-01-02"))
+
+	cmd := exec.Command("git", "log", since, "--name-only", "--pretty=format:")
+	cmd.Dir = repoRoot
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, "git log")
+	}
+
+	counts := make(map[string]int)
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		counts[line]++
+	}
+
+	return counts, errors.Wrap(scanner.Err(), "read git log output")
+}
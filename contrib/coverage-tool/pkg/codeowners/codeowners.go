@@ -0,0 +1,85 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package codeowners parses GitHub CODEOWNERS files so coverage-tool can
+// scope selection to files owned by a particular team.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Rule is a single CODEOWNERS pattern-to-owners mapping.
+type Rule struct {
+	Pattern string
+	Owners  []string
+}
+
+// Rules is an ordered list of CODEOWNERS rules. As in GitHub's own
+// semantics, later rules take precedence over earlier ones for the same
+// path.
+type Rules []Rule
+
+// Parse reads a CODEOWNERS file, skipping blank lines and comments.
+func Parse(r io.Reader) (Rules, error) {
+	var rules Rules
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:]})
+	}
+
+	return rules, errors.Wrap(scanner.Err(), "read CODEOWNERS")
+}
+
+// OwnersFor returns the owners of path, per the last matching rule, or nil
+// if no rule matches.
+func (rules Rules) OwnersFor(path string) []string {
+	var owners []string
+	for _, rule := range rules {
+		if matches(rule.Pattern, path) {
+			owners = rule.Owners
+		}
+	}
+	return owners
+}
+
+// matches reports whether pattern (in CODEOWNERS syntax) matches path. It
+// supports the common subset used across nydus: a leading "/" anchors to
+// the repo root, a trailing "/" matches a whole directory, and "*" is a
+// glob wildcard; anything else is treated as a path prefix.
+func matches(pattern, path string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(path, pattern)
+	}
+
+	if strings.Contains(pattern, "*") {
+		if ok, err := filepath.Match(pattern, path); err == nil && ok {
+			return true
+		}
+		if ok, err := filepath.Match(pattern, filepath.Base(path)); err == nil && ok {
+			return true
+		}
+		return false
+	}
+
+	return path == pattern || strings.HasPrefix(path, strings.TrimSuffix(pattern, "/")+"/")
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package codeowners
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = `
+# comment
+storage/ @dragonflyoss/storage-team
+*.md @dragonflyoss/docs-team
+/rafs/src/metadata/mod.rs @dragonflyoss/rafs-team @some-user
+`
+
+func TestOwnersFor(t *testing.T) {
+	rules, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"storage/src/device.rs", []string{"@dragonflyoss/storage-team"}},
+		{"README.md", []string{"@dragonflyoss/docs-team"}},
+		{"rafs/src/metadata/mod.rs", []string{"@dragonflyoss/rafs-team", "@some-user"}},
+		{"builder/src/lib.rs", nil},
+	}
+
+	for _, tt := range tests {
+		got := rules.OwnersFor(tt.path)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("OwnersFor(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package compare renders a comparison report across the providers/models
+// `compare-models` generated and validated tests with for the same target
+// file, so a team can pick a default empirically instead of by hunch.
+package compare
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Candidate is one provider/model pairing to compare.
+type Candidate struct {
+	// Label identifies the candidate in the report, e.g. "mini" or
+	// "local".
+	Label string
+	// Provider is the --llm-provider name to generate with.
+	Provider string
+	// Model is the model id to request from Provider.
+	Model string
+}
+
+// ParseCandidate parses a --candidate flag value of the form
+// "label=provider:model", e.g. "mini=github-models:gpt-4o-mini".
+func ParseCandidate(spec string) (Candidate, error) {
+	label, rest, ok := strings.Cut(spec, "=")
+	if !ok || label == "" {
+		return Candidate{}, errors.Errorf("--candidate %q must be of the form label=provider:model", spec)
+	}
+
+	provider, model, ok := strings.Cut(rest, ":")
+	if !ok || provider == "" || model == "" {
+		return Candidate{}, errors.Errorf("--candidate %q must be of the form label=provider:model", spec)
+	}
+
+	return Candidate{Label: label, Provider: provider, Model: model}, nil
+}
+
+// Result is a single Candidate's generation and validation outcome.
+type Result struct {
+	Candidate Candidate
+	// Compiled reports whether the candidate's generated test passed
+	// validation.
+	Compiled bool
+	// CoveragePercent is the target file's line coverage with the
+	// candidate's test applied, if Compiled.
+	CoveragePercent float64
+	// Delta is CoveragePercent minus the run's coverage baseline, if
+	// Compiled.
+	Delta float64
+	// Cost is the estimated USD cost of the candidate's generation call.
+	Cost float64
+	// Reason explains why the candidate failed, if !Compiled.
+	Reason string
+}
+
+// Markdown renders results as a markdown comparison table for file, sorted
+// with compiling candidates first, ranked by coverage delta descending.
+func Markdown(file string, results []Result) string {
+	sorted := make([]Result, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Compiled != sorted[j].Compiled {
+			return sorted[i].Compiled
+		}
+		return sorted[i].Delta > sorted[j].Delta
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Model comparison: %s\n\n", file)
+	fmt.Fprintf(&buf, "| Candidate | Provider | Model | Compiled | Coverage | Delta | Cost | Notes |\n")
+	fmt.Fprintf(&buf, "|---|---|---|---|---|---|---|---|\n")
+	for _, r := range sorted {
+		compiled, coverage, delta := "no", "-", "-"
+		if r.Compiled {
+			compiled = "yes"
+			coverage = fmt.Sprintf("%.2f%%", r.CoveragePercent)
+			delta = fmt.Sprintf("%+.2f%%", r.Delta)
+		}
+		fmt.Fprintf(&buf, "| %s | %s | %s | %s | %s | %s | $%.4f | %s |\n",
+			r.Candidate.Label, r.Candidate.Provider, r.Candidate.Model, compiled, coverage, delta, r.Cost, r.Reason)
+	}
+
+	return buf.String()
+}
+
+// WriteMarkdown renders results for file and writes them to path.
+func WriteMarkdown(path, file string, results []Result) error {
+	return errors.Wrap(os.WriteFile(path, []byte(Markdown(file, results)), 0o644), "write comparison report")
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCandidate(t *testing.T) {
+	c, err := ParseCandidate("mini=github-models:gpt-4o-mini")
+	if err != nil {
+		t.Fatalf("ParseCandidate returned error: %v", err)
+	}
+	if c.Label != "mini" || c.Provider != "github-models" || c.Model != "gpt-4o-mini" {
+		t.Errorf("ParseCandidate() = %+v, want {mini github-models gpt-4o-mini}", c)
+	}
+}
+
+func TestParseCandidateRejectsMalformedSpecs(t *testing.T) {
+	for _, spec := range []string{"", "mini", "mini=github-models", "=github-models:gpt-4o-mini", "mini=:gpt-4o-mini"} {
+		if _, err := ParseCandidate(spec); err == nil {
+			t.Errorf("ParseCandidate(%q) should return an error", spec)
+		}
+	}
+}
+
+func TestMarkdownRanksCompiledCandidatesByDeltaDescending(t *testing.T) {
+	results := []Result{
+		{Candidate: Candidate{Label: "local"}, Compiled: false, Reason: "validation failed"},
+		{Candidate: Candidate{Label: "mini"}, Compiled: true, Delta: 5, CoveragePercent: 80},
+		{Candidate: Candidate{Label: "big"}, Compiled: true, Delta: 12, CoveragePercent: 87},
+	}
+
+	md := Markdown("storage/src/device.rs", results)
+
+	big := strings.Index(md, "| big ")
+	mini := strings.Index(md, "| mini ")
+	local := strings.Index(md, "| local ")
+	if big == -1 || mini == -1 || local == -1 {
+		t.Fatalf("Markdown() missing a candidate row:\n%s", md)
+	}
+	if !(big < mini && mini < local) {
+		t.Errorf("Markdown() should rank compiled candidates by delta descending, then failed ones, got:\n%s", md)
+	}
+	if !strings.Contains(md, "validation failed") {
+		t.Errorf("Markdown() should include the failure reason, got:\n%s", md)
+	}
+}
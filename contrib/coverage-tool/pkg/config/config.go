@@ -0,0 +1,165 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config loads coverage-tool's policy configuration, optionally
+// inheriting a shared base so an organization can enforce common
+// providers, redaction, and coverage thresholds across every repository
+// that uses the tool.
+package config
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// httpClient bounds how long fetching a remote base config may take, so
+// an unreachable org config server fails fast instead of hanging a run.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Config is coverage-tool's policy configuration. Zero values are treated
+// as "not set" so a local Config can selectively override a base one.
+type Config struct {
+	// Extends points at a base config to inherit from, either an
+	// https:// URL or a path to another config file. It is not itself
+	// inherited.
+	Extends string `yaml:"extends,omitempty"`
+
+	Provider      string   `yaml:"provider,omitempty" toml:"provider,omitempty"`
+	RedactSecrets *bool    `yaml:"redact_secrets,omitempty" toml:"redact_secrets,omitempty"`
+	MinCoverage   *float64 `yaml:"min_coverage,omitempty" toml:"min_coverage,omitempty"`
+	WarnCoverage  *float64 `yaml:"warn_coverage,omitempty" toml:"warn_coverage,omitempty"`
+
+	// Strategy is the selection strategy analyze ranks candidates by
+	// (coverage, round-robin, churn, zero-first, regression).
+	Strategy string `yaml:"strategy,omitempty" toml:"strategy,omitempty"`
+	// ValidateCommand overrides generate's --backend-derived validate
+	// command with a project-specific one, e.g. a wrapper script.
+	ValidateCommand string `yaml:"validate_command,omitempty" toml:"validate_command,omitempty"`
+	// ValidateRunner is the test runner (cargo, nextest) generate and
+	// validate run validation commands with.
+	ValidateRunner string `yaml:"validate_runner,omitempty" toml:"validate_runner,omitempty"`
+	// MinDelta is the minimum coverage-percentage-point improvement a
+	// generated candidate must reach over its baseline to be accepted.
+	MinDelta *float64 `yaml:"min_delta,omitempty" toml:"min_delta,omitempty"`
+	// ReportFormat is the format report renders to (markdown, html, json).
+	ReportFormat string `yaml:"report_format,omitempty" toml:"report_format,omitempty"`
+}
+
+// Load reads the config at path and, if it declares extends, fetches and
+// merges it on top of that base config, with values in path taking
+// precedence over the base. extends may chain to another config of its
+// own, up to depth levels, guarding against a cycle.
+func Load(path string) (*Config, error) {
+	return load(path, 10)
+}
+
+func load(path string, depth int) (*Config, error) {
+	if depth <= 0 {
+		return nil, errors.New("config extends chain is too deep (possible cycle)")
+	}
+
+	local, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if local.Extends == "" {
+		return local, nil
+	}
+
+	base, err := load(local.Extends, depth-1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load base config %q", local.Extends)
+	}
+
+	return merge(base, local), nil
+}
+
+// read fetches raw config bytes from an https:// URL or a local path and
+// unmarshals them, as TOML if path ends in .toml and as YAML otherwise.
+func read(path string) (*Config, error) {
+	var r io.ReadCloser
+
+	if isURL(path) {
+		resp, err := httpClient.Get(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch config %q", path)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, errors.Errorf("fetching config %q returned status %d", path, resp.StatusCode)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "open config %q", path)
+		}
+		r = f
+	}
+	defer r.Close()
+
+	var cfg Config
+	var decodeErr error
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		_, decodeErr = toml.NewDecoder(r).Decode(&cfg)
+	} else {
+		decodeErr = yaml.NewDecoder(r).Decode(&cfg)
+	}
+	if decodeErr != nil {
+		return nil, errors.Wrapf(decodeErr, "parse config %q", path)
+	}
+
+	return &cfg, nil
+}
+
+// merge overlays local's set fields onto base, so an organization's base
+// policy is the default and a repository only needs to specify what it
+// wants to override.
+func merge(base, local *Config) *Config {
+	merged := *base
+
+	if local.Provider != "" {
+		merged.Provider = local.Provider
+	}
+	if local.RedactSecrets != nil {
+		merged.RedactSecrets = local.RedactSecrets
+	}
+	if local.MinCoverage != nil {
+		merged.MinCoverage = local.MinCoverage
+	}
+	if local.WarnCoverage != nil {
+		merged.WarnCoverage = local.WarnCoverage
+	}
+	if local.Strategy != "" {
+		merged.Strategy = local.Strategy
+	}
+	if local.ValidateCommand != "" {
+		merged.ValidateCommand = local.ValidateCommand
+	}
+	if local.ValidateRunner != "" {
+		merged.ValidateRunner = local.ValidateRunner
+	}
+	if local.MinDelta != nil {
+		merged.MinDelta = local.MinDelta
+	}
+	if local.ReportFormat != "" {
+		merged.ReportFormat = local.ReportFormat
+	}
+	merged.Extends = ""
+
+	return &merged
+}
+
+func isURL(path string) bool {
+	return strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://")
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	return path
+}
+
+func TestLoadMergesLocalOverBase(t *testing.T) {
+	dir := t.TempDir()
+	basePath := writeConfig(t, dir, "base.yaml", "provider: openai\nmin_coverage: 60\nwarn_coverage: 80\n")
+	localPath := writeConfig(t, dir, "local.yaml", "extends: "+basePath+"\nmin_coverage: 70\n")
+
+	cfg, err := Load(localPath)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if cfg.Provider != "openai" {
+		t.Errorf("Provider = %q, want inherited %q", cfg.Provider, "openai")
+	}
+	if cfg.MinCoverage == nil || *cfg.MinCoverage != 70 {
+		t.Errorf("MinCoverage = %v, want local override 70", cfg.MinCoverage)
+	}
+	if cfg.WarnCoverage == nil || *cfg.WarnCoverage != 80 {
+		t.Errorf("WarnCoverage = %v, want inherited 80", cfg.WarnCoverage)
+	}
+}
+
+func TestLoadWithoutExtends(t *testing.T) {
+	dir := t.TempDir()
+	path := writeConfig(t, dir, "solo.yaml", "provider: ollama\n")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Provider != "ollama" {
+		t.Errorf("Provider = %q, want %q", cfg.Provider, "ollama")
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := writeConfig(t, dir, "solo.toml", "provider = \"ollama\"\nstrategy = \"churn\"\nmin_delta = 2.5\nreport_format = \"html\"\n")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Provider != "ollama" {
+		t.Errorf("Provider = %q, want %q", cfg.Provider, "ollama")
+	}
+	if cfg.Strategy != "churn" {
+		t.Errorf("Strategy = %q, want %q", cfg.Strategy, "churn")
+	}
+	if cfg.MinDelta == nil || *cfg.MinDelta != 2.5 {
+		t.Errorf("MinDelta = %v, want 2.5", cfg.MinDelta)
+	}
+	if cfg.ReportFormat != "html" {
+		t.Errorf("ReportFormat = %q, want %q", cfg.ReportFormat, "html")
+	}
+}
+
+func TestLoadTOMLExtendsYAMLBase(t *testing.T) {
+	dir := t.TempDir()
+	basePath := writeConfig(t, dir, "base.yaml", "provider: openai\nvalidate_runner: nextest\n")
+	localPath := writeConfig(t, dir, "local.toml", "extends = \""+basePath+"\"\nstrategy = \"zero-first\"\n")
+
+	cfg, err := Load(localPath)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Provider != "openai" {
+		t.Errorf("Provider = %q, want inherited %q", cfg.Provider, "openai")
+	}
+	if cfg.ValidateRunner != "nextest" {
+		t.Errorf("ValidateRunner = %q, want inherited %q", cfg.ValidateRunner, "nextest")
+	}
+	if cfg.Strategy != "zero-first" {
+		t.Errorf("Strategy = %q, want %q", cfg.Strategy, "zero-first")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cost
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// spend records a single generate call's estimated dollar cost.
+type spend struct {
+	Timestamp time.Time `json:"timestamp"`
+	File      string    `json:"file"`
+	Model     string    `json:"model"`
+	USD       float64   `json:"usd"`
+}
+
+// Budget is a file-backed running total of estimated spend, so a
+// --max-cost cap holds across coverage-tool's separate generate
+// invocations instead of resetting on every run.
+type Budget struct {
+	path   string
+	MaxUSD float64 `json:"maxUsd"`
+	Spends []spend `json:"spends"`
+}
+
+// OpenBudget loads the cost ledger at path, creating an empty one if it
+// does not yet exist.
+func OpenBudget(path string, maxUSD float64) (*Budget, error) {
+	b := &Budget{path: path, MaxUSD: maxUSD}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read cost ledger")
+	}
+
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, errors.Wrap(err, "parse cost ledger")
+	}
+	b.path = path
+	b.MaxUSD = maxUSD
+
+	return b, nil
+}
+
+// Spent returns the total estimated spend recorded so far.
+func (b *Budget) Spent() float64 {
+	var total float64
+	for _, s := range b.Spends {
+		total += s.USD
+	}
+	return total
+}
+
+// Exceeded reports whether Spent has already reached MaxUSD. A zero
+// MaxUSD disables the check.
+func (b *Budget) Exceeded() bool {
+	return b.MaxUSD > 0 && b.Spent() >= b.MaxUSD
+}
+
+// Record appends a spend entry and persists the ledger.
+func (b *Budget) Record(file, model string, usd float64) error {
+	b.Spends = append(b.Spends, spend{Timestamp: time.Now(), File: file, Model: model, USD: usd})
+	return b.save()
+}
+
+func (b *Budget) save() error {
+	if b.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o755); err != nil {
+		return errors.Wrap(err, "create cost ledger directory")
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal cost ledger")
+	}
+
+	return errors.Wrap(os.WriteFile(b.path, data, 0o644), "write cost ledger")
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cost
+
+import (
+	"testing"
+)
+
+func TestBudgetRecordPersistsAcrossOpen(t *testing.T) {
+	path := t.TempDir() + "/cost.json"
+
+	b, err := OpenBudget(path, 0)
+	if err != nil {
+		t.Fatalf("OpenBudget returned error: %v", err)
+	}
+	if err := b.Record("storage/src/device.rs", "gpt-4o-mini", 0.05); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+
+	reopened, err := OpenBudget(path, 0)
+	if err != nil {
+		t.Fatalf("re-OpenBudget returned error: %v", err)
+	}
+	if got := reopened.Spent(); got != 0.05 {
+		t.Errorf("Spent() = %v, want 0.05", got)
+	}
+}
+
+func TestBudgetExceededRespectsMaxUSD(t *testing.T) {
+	b, err := OpenBudget(t.TempDir()+"/cost.json", 1.0)
+	if err != nil {
+		t.Fatalf("OpenBudget returned error: %v", err)
+	}
+
+	if b.Exceeded() {
+		t.Error("Exceeded() should be false before any spend is recorded")
+	}
+	if err := b.Record("storage/src/device.rs", "gpt-4o-mini", 1.5); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if !b.Exceeded() {
+		t.Error("Exceeded() should be true once spend reaches MaxUSD")
+	}
+}
+
+func TestBudgetExceededDisabledWhenMaxUSDIsZero(t *testing.T) {
+	b, err := OpenBudget(t.TempDir()+"/cost.json", 0)
+	if err != nil {
+		t.Fatalf("OpenBudget returned error: %v", err)
+	}
+	_ = b.Record("storage/src/device.rs", "gpt-4o-mini", 1_000_000)
+
+	if b.Exceeded() {
+		t.Error("Exceeded() should always be false when MaxUSD is 0")
+	}
+}
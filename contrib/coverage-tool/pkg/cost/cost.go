@@ -0,0 +1,64 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cost estimates the dollar cost of an llm.Usage against a
+// configurable per-model price table, and tracks accumulated spend
+// against a --max-cost budget shared across coverage-tool's separate
+// generate invocations.
+package cost
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+)
+
+// Price is a model's cost per million prompt and completion tokens, in US
+// dollars.
+type Price struct {
+	PromptPerMillion     float64 `json:"promptPerMillion"`
+	CompletionPerMillion float64 `json:"completionPerMillion"`
+}
+
+// Table maps a model id to its Price. A model absent from the table costs
+// 0 rather than erroring, since an unpriced model is more likely a custom
+// or local one than a mistake.
+type Table map[string]Price
+
+// defaultTable prices the models coverage-tool ships defaults for.
+var defaultTable = Table{
+	"gpt-4o-mini": {PromptPerMillion: 0.15, CompletionPerMillion: 0.60},
+	"gpt-4o":      {PromptPerMillion: 2.50, CompletionPerMillion: 10.00},
+}
+
+// LoadTable reads a JSON-encoded price table from path, or returns
+// coverage-tool's built-in defaults if path is empty.
+func LoadTable(path string) (Table, error) {
+	if path == "" {
+		return defaultTable, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read price table")
+	}
+
+	var table Table
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, errors.Wrap(err, "parse price table")
+	}
+
+	return table, nil
+}
+
+// Estimate returns the dollar cost of usage against model's price in
+// table, or 0 if model is not priced.
+func Estimate(table Table, model string, usage llm.Usage) float64 {
+	price := table[model]
+	return float64(usage.PromptTokens)/1e6*price.PromptPerMillion +
+		float64(usage.CompletionTokens)/1e6*price.CompletionPerMillion
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cost
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/llm"
+)
+
+func TestEstimateUsesDefaultTableForKnownModel(t *testing.T) {
+	table, err := LoadTable("")
+	if err != nil {
+		t.Fatalf("LoadTable returned error: %v", err)
+	}
+
+	got := Estimate(table, "gpt-4o-mini", llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000_000})
+	want := 0.15 + 0.60
+	if got != want {
+		t.Errorf("Estimate() = %v, want %v", got, want)
+	}
+}
+
+func TestEstimateIsZeroForUnpricedModel(t *testing.T) {
+	table, _ := LoadTable("")
+	if got := Estimate(table, "some-local-model", llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000_000}); got != 0 {
+		t.Errorf("Estimate() = %v, want 0 for an unpriced model", got)
+	}
+}
+
+func TestLoadTableReadsCustomPriceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	if err := os.WriteFile(path, []byte(`{"my-model": {"promptPerMillion": 1, "completionPerMillion": 2}}`), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	table, err := LoadTable(path)
+	if err != nil {
+		t.Fatalf("LoadTable returned error: %v", err)
+	}
+
+	got := Estimate(table, "my-model", llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000_000})
+	if got != 3 {
+		t.Errorf("Estimate() = %v, want 3", got)
+	}
+}
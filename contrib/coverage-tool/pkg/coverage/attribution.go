@@ -0,0 +1,157 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PerTestProfile is a coverage report attributed to a single test, as
+// produced by running `cargo llvm-cov --json` once per test (or via
+// nextest's per-test profile export) and saving each export under its
+// test name.
+type PerTestProfile struct {
+	TestName string
+	Report   *Report
+}
+
+// LoadPerTestProfiles reads every `*.json` llvm-cov export in dir,
+// treating each file's name (without extension) as the test that
+// produced it.
+func LoadPerTestProfiles(dir string) ([]PerTestProfile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read per-test profile dir %s", dir)
+	}
+
+	var profiles []PerTestProfile
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		f, err := os.Open(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "open per-test profile %s", entry.Name())
+		}
+		report, err := Parse(f)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse per-test profile %s", entry.Name())
+		}
+
+		testName := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		profiles = append(profiles, PerTestProfile{TestName: testName, Report: report})
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].TestName < profiles[j].TestName })
+	return profiles, nil
+}
+
+// Attribution reports, for a single file, which tests exercise it (any
+// line covered) and whether it is covered by only one test.
+type Attribution struct {
+	Path         string
+	CoveredBy    []string
+	SingleTested bool
+}
+
+// Attribute reports which of profiles cover each file with at least one
+// covered line, so maintainers can find tests whose coverage is entirely
+// redundant with another test, or files relying on a single test with no
+// backup coverage.
+func Attribute(profiles []PerTestProfile) []Attribution {
+	coveredBy := make(map[string][]string)
+	var order []string
+	for _, profile := range profiles {
+		for _, file := range profile.Report.Files {
+			if file.LinesCovered == 0 {
+				continue
+			}
+			if _, seen := coveredBy[file.Path]; !seen {
+				order = append(order, file.Path)
+			}
+			coveredBy[file.Path] = append(coveredBy[file.Path], profile.TestName)
+		}
+	}
+	sort.Strings(order)
+
+	attributions := make([]Attribution, 0, len(order))
+	for _, path := range order {
+		tests := coveredBy[path]
+		attributions = append(attributions, Attribution{
+			Path:         path,
+			CoveredBy:    tests,
+			SingleTested: len(tests) == 1,
+		})
+	}
+	return attributions
+}
+
+// coveredLineSet is the set of files a profile covers at all, used to
+// approximate one test's covered region as a subset of another's without
+// requiring line-level profile data.
+func coveredLineSet(report *Report) map[string]bool {
+	set := make(map[string]bool, len(report.Files))
+	for _, f := range report.Files {
+		if f.LinesCovered > 0 {
+			set[f.Path] = true
+		}
+	}
+	return set
+}
+
+// isSubsetOf reports whether every file covered by subset is also covered
+// by superset, and superset covers at least one file subset doesn't.
+func isSubsetOf(subset, superset map[string]bool) bool {
+	if len(subset) == 0 || len(subset) >= len(superset) {
+		return false
+	}
+	for path := range subset {
+		if !superset[path] {
+			return false
+		}
+	}
+	return true
+}
+
+// RedundantTest names a test whose covered files are a strict subset of
+// another test's, making it a candidate for removal.
+type RedundantTest struct {
+	TestName   string
+	SubsumedBy string
+}
+
+// FindRedundant reports, for each test in profiles, whether another test
+// covers a strict superset of its files — i.e. removing the redundant
+// test would not shrink the set of files under test.
+func FindRedundant(profiles []PerTestProfile) []RedundantTest {
+	sets := make([]map[string]bool, len(profiles))
+	for i, p := range profiles {
+		sets[i] = coveredLineSet(p.Report)
+	}
+
+	var redundant []RedundantTest
+	for i, subset := range sets {
+		for j, superset := range sets {
+			if i == j {
+				continue
+			}
+			if isSubsetOf(subset, superset) {
+				redundant = append(redundant, RedundantTest{
+					TestName:   profiles[i].TestName,
+					SubsumedBy: profiles[j].TestName,
+				})
+				break
+			}
+		}
+	}
+	return redundant
+}
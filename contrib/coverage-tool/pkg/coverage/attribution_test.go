@@ -0,0 +1,70 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import "testing"
+
+func TestAttributeFlagsSingleTestedFiles(t *testing.T) {
+	profiles := []PerTestProfile{
+		{TestName: "test_a", Report: &Report{Files: []FileStats{
+			{Path: "storage/src/device.rs", LinesCovered: 40, LinesTotal: 100},
+			{Path: "storage/src/only_a.rs", LinesCovered: 5, LinesTotal: 10},
+		}}},
+		{TestName: "test_b", Report: &Report{Files: []FileStats{
+			{Path: "storage/src/device.rs", LinesCovered: 20, LinesTotal: 100},
+			{Path: "storage/src/untouched.rs", LinesCovered: 0, LinesTotal: 10},
+		}}},
+	}
+
+	attributions := Attribute(profiles)
+	if len(attributions) != 2 {
+		t.Fatalf("expected 2 covered files, got %d: %+v", len(attributions), attributions)
+	}
+
+	byPath := make(map[string]Attribution, len(attributions))
+	for _, a := range attributions {
+		byPath[a.Path] = a
+	}
+
+	device := byPath["storage/src/device.rs"]
+	if device.SingleTested {
+		t.Error("device.rs is covered by two tests, should not be flagged SingleTested")
+	}
+	if len(device.CoveredBy) != 2 {
+		t.Errorf("device.rs CoveredBy = %v, want 2 tests", device.CoveredBy)
+	}
+
+	onlyA := byPath["storage/src/only_a.rs"]
+	if !onlyA.SingleTested {
+		t.Error("only_a.rs is covered by a single test, should be flagged SingleTested")
+	}
+
+	if _, found := byPath["storage/src/untouched.rs"]; found {
+		t.Error("untouched.rs has zero covered lines and should not appear in the attribution")
+	}
+}
+
+func TestFindRedundantFlagsStrictSubsets(t *testing.T) {
+	profiles := []PerTestProfile{
+		{TestName: "test_broad", Report: &Report{Files: []FileStats{
+			{Path: "a.rs", LinesCovered: 10, LinesTotal: 10},
+			{Path: "b.rs", LinesCovered: 10, LinesTotal: 10},
+		}}},
+		{TestName: "test_narrow", Report: &Report{Files: []FileStats{
+			{Path: "a.rs", LinesCovered: 5, LinesTotal: 10},
+		}}},
+		{TestName: "test_unique", Report: &Report{Files: []FileStats{
+			{Path: "c.rs", LinesCovered: 1, LinesTotal: 10},
+		}}},
+	}
+
+	redundant := FindRedundant(profiles)
+	if len(redundant) != 1 {
+		t.Fatalf("expected 1 redundant test, got %d: %+v", len(redundant), redundant)
+	}
+	if redundant[0].TestName != "test_narrow" || redundant[0].SubsumedBy != "test_broad" {
+		t.Errorf("unexpected redundant entry: %+v", redundant[0])
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package coverage parses `cargo llvm-cov --json` export files and exposes
+// per-file coverage statistics used by the selection, generation and
+// reporting stages of coverage-tool.
+package coverage
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// FileStats holds coverage counters for a single source file.
+type FileStats struct {
+	Path             string `json:"path"`
+	LinesCovered     int    `json:"lines_covered"`
+	LinesTotal       int    `json:"lines_total"`
+	FunctionsCovered int    `json:"functions_covered"`
+	FunctionsTotal   int    `json:"functions_total"`
+	RegionsCovered   int    `json:"regions_covered"`
+	RegionsTotal     int    `json:"regions_total"`
+}
+
+// Percent returns the line coverage percentage for the file, in [0, 100].
+// Files with no lines report 100% since there is nothing left to cover.
+func (f FileStats) Percent() float64 {
+	if f.LinesTotal == 0 {
+		return 100
+	}
+	return float64(f.LinesCovered) / float64(f.LinesTotal) * 100
+}
+
+// FunctionCoverage records whether a single function was ever executed
+// during the profiled test run.
+type FunctionCoverage struct {
+	Name     string `json:"name"`
+	File     string `json:"file"`
+	Executed bool   `json:"executed"`
+}
+
+// Report is a full coverage snapshot for the workspace, as produced by
+// `cargo llvm-cov --json` and normalized by Parse.
+type Report struct {
+	Files     []FileStats        `json:"files"`
+	Functions []FunctionCoverage `json:"functions"`
+}
+
+// Percent returns the workspace-wide line coverage percentage across every
+// file in the report, in [0, 100]. Reports with no lines report 100%.
+func (r Report) Percent() float64 {
+	var covered, total int
+	for _, f := range r.Files {
+		covered += f.LinesCovered
+		total += f.LinesTotal
+	}
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) / float64(total) * 100
+}
+
+// llvmCovExport is the subset of `cargo llvm-cov export=json` we rely on.
+type llvmCovExport struct {
+	Data []struct {
+		Files []struct {
+			Filename string `json:"filename"`
+			Summary  struct {
+				Lines     llvmCovCounter `json:"lines"`
+				Functions llvmCovCounter `json:"functions"`
+				Regions   llvmCovCounter `json:"regions"`
+			} `json:"summary"`
+		} `json:"files"`
+		Functions []struct {
+			Name      string   `json:"name"`
+			Count     int      `json:"count"`
+			Filenames []string `json:"filenames"`
+		} `json:"functions"`
+	} `json:"data"`
+}
+
+type llvmCovCounter struct {
+	Count   int `json:"count"`
+	Covered int `json:"covered"`
+}
+
+// Parse reads a `cargo llvm-cov --json` export and returns per-file stats.
+func Parse(r io.Reader) (*Report, error) {
+	var export llvmCovExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, errors.Wrap(err, "decode llvm-cov json export")
+	}
+
+	report := &Report{}
+	for _, data := range export.Data {
+		for _, file := range data.Files {
+			report.Files = append(report.Files, FileStats{
+				Path:             file.Filename,
+				LinesCovered:     file.Summary.Lines.Covered,
+				LinesTotal:       file.Summary.Lines.Count,
+				FunctionsCovered: file.Summary.Functions.Covered,
+				FunctionsTotal:   file.Summary.Functions.Count,
+				RegionsCovered:   file.Summary.Regions.Covered,
+				RegionsTotal:     file.Summary.Regions.Count,
+			})
+		}
+		for _, fn := range data.Functions {
+			for _, filename := range fn.Filenames {
+				report.Functions = append(report.Functions, FunctionCoverage{
+					Name:     fn.Name,
+					File:     filename,
+					Executed: fn.Count > 0,
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleExport = `{
+	"data": [{
+		"files": [{
+			"filename": "storage/src/device.rs",
+			"summary": {
+				"lines": {"count": 100, "covered": 40},
+				"functions": {"count": 10, "covered": 4},
+				"regions": {"count": 50, "covered": 20}
+			}
+		}]
+	}]
+}`
+
+func TestParse(t *testing.T) {
+	report, err := Parse(strings.NewReader(sampleExport))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if len(report.Files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(report.Files))
+	}
+
+	f := report.Files[0]
+	if f.Path != "storage/src/device.rs" {
+		t.Errorf("unexpected path: %s", f.Path)
+	}
+	if got, want := f.Percent(), 40.0; got != want {
+		t.Errorf("Percent() = %v, want %v", got, want)
+	}
+}
+
+func TestFileStatsPercentNoLines(t *testing.T) {
+	f := FileStats{}
+	if got := f.Percent(); got != 100 {
+		t.Errorf("Percent() with no lines = %v, want 100", got)
+	}
+}
+
+func TestReportPercentAggregatesAcrossFiles(t *testing.T) {
+	report := Report{Files: []FileStats{
+		{LinesCovered: 40, LinesTotal: 100},
+		{LinesCovered: 10, LinesTotal: 100},
+	}}
+	if got, want := report.Percent(), 25.0; got != want {
+		t.Errorf("Percent() = %v, want %v", got, want)
+	}
+}
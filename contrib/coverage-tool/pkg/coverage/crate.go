@@ -0,0 +1,55 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import "sort"
+
+// CrateStats holds line coverage counters aggregated across every file
+// belonging to one Cargo workspace member.
+type CrateStats struct {
+	Crate        string
+	LinesCovered int
+	LinesTotal   int
+}
+
+// Percent returns the crate's aggregate line coverage percentage, in
+// [0, 100]. Crates with no lines report 100% since there is nothing left
+// to cover.
+func (c CrateStats) Percent() float64 {
+	if c.LinesTotal == 0 {
+		return 100
+	}
+	return float64(c.LinesCovered) / float64(c.LinesTotal) * 100
+}
+
+// RollupByCrate aggregates files into one CrateStats per crate name
+// returned by crateOf, sorted by ascending coverage percentage so the
+// least-covered crates are listed first. Files for which crateOf returns
+// "" are excluded, since they don't belong to any known workspace member.
+func RollupByCrate(files []FileStats, crateOf func(path string) string) []CrateStats {
+	byCrate := make(map[string]*CrateStats)
+	for _, file := range files {
+		crate := crateOf(file.Path)
+		if crate == "" {
+			continue
+		}
+		c, ok := byCrate[crate]
+		if !ok {
+			c = &CrateStats{Crate: crate}
+			byCrate[crate] = c
+		}
+		c.LinesCovered += file.LinesCovered
+		c.LinesTotal += file.LinesTotal
+	}
+
+	rollup := make([]CrateStats, 0, len(byCrate))
+	for _, c := range byCrate {
+		rollup = append(rollup, *c)
+	}
+	sort.Slice(rollup, func(i, j int) bool {
+		return rollup[i].Percent() < rollup[j].Percent()
+	})
+	return rollup
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import "testing"
+
+func TestRollupByCrate(t *testing.T) {
+	files := []FileStats{
+		{Path: "storage/src/a.rs", LinesCovered: 8, LinesTotal: 10},
+		{Path: "storage/src/b.rs", LinesCovered: 2, LinesTotal: 10},
+		{Path: "rafs/src/c.rs", LinesCovered: 5, LinesTotal: 5},
+		{Path: "unknown.rs", LinesCovered: 0, LinesTotal: 1},
+	}
+
+	crateOf := func(path string) string {
+		switch {
+		case path == "storage/src/a.rs" || path == "storage/src/b.rs":
+			return "storage"
+		case path == "rafs/src/c.rs":
+			return "rafs"
+		default:
+			return ""
+		}
+	}
+
+	rollup := RollupByCrate(files, crateOf)
+	if len(rollup) != 2 {
+		t.Fatalf("len(rollup) = %d, want 2", len(rollup))
+	}
+	if rollup[0].Crate != "storage" || rollup[0].Percent() != 50 {
+		t.Errorf("rollup[0] = %+v, want storage at 50%%", rollup[0])
+	}
+	if rollup[1].Crate != "rafs" || rollup[1].Percent() != 100 {
+		t.Errorf("rollup[1] = %+v, want rafs at 100%%", rollup[1])
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import "sort"
+
+// FileDelta is one file's line coverage change between two reports.
+type FileDelta struct {
+	Path          string
+	BeforePercent float64
+	AfterPercent  float64
+}
+
+// Delta returns the change in line coverage percentage, negative for a
+// regression.
+func (d FileDelta) Delta() float64 {
+	return d.AfterPercent - d.BeforePercent
+}
+
+// Diff compares before and after by path, returning one FileDelta per path
+// that appears in either, sorted by ascending Delta so the worst
+// regressions come first. A path missing from one side is treated as 0%
+// (a new file starts at 0% before it existed; a removed file ends at 0%),
+// rather than FileStats{}'s "no lines to cover" 100%.
+func Diff(before, after []FileStats) []FileDelta {
+	beforeByPath := make(map[string]FileStats, len(before))
+	for _, f := range before {
+		beforeByPath[f.Path] = f
+	}
+	afterByPath := make(map[string]FileStats, len(after))
+	for _, f := range after {
+		afterByPath[f.Path] = f
+	}
+
+	paths := make(map[string]struct{}, len(beforeByPath)+len(afterByPath))
+	for p := range beforeByPath {
+		paths[p] = struct{}{}
+	}
+	for p := range afterByPath {
+		paths[p] = struct{}{}
+	}
+
+	deltas := make([]FileDelta, 0, len(paths))
+	for p := range paths {
+		var beforePercent, afterPercent float64
+		if f, ok := beforeByPath[p]; ok {
+			beforePercent = f.Percent()
+		}
+		if f, ok := afterByPath[p]; ok {
+			afterPercent = f.Percent()
+		}
+		deltas = append(deltas, FileDelta{
+			Path:          p,
+			BeforePercent: beforePercent,
+			AfterPercent:  afterPercent,
+		})
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Delta() < deltas[j].Delta()
+	})
+
+	return deltas
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	before := []FileStats{
+		{Path: "a.rs", LinesCovered: 8, LinesTotal: 10},
+		{Path: "b.rs", LinesCovered: 5, LinesTotal: 10},
+	}
+	after := []FileStats{
+		{Path: "a.rs", LinesCovered: 4, LinesTotal: 10},
+		{Path: "b.rs", LinesCovered: 9, LinesTotal: 10},
+		{Path: "c.rs", LinesCovered: 3, LinesTotal: 10},
+	}
+
+	deltas := Diff(before, after)
+	if len(deltas) != 3 {
+		t.Fatalf("len(deltas) = %d, want 3", len(deltas))
+	}
+
+	if deltas[0].Path != "a.rs" || deltas[0].Delta() >= 0 {
+		t.Errorf("worst delta = %+v, want a.rs with a negative delta", deltas[0])
+	}
+	if deltas[len(deltas)-1].Path != "b.rs" || deltas[len(deltas)-1].Delta() <= 0 {
+		t.Errorf("best delta = %+v, want b.rs with a positive delta", deltas[len(deltas)-1])
+	}
+}
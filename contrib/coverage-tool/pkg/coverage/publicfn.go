@@ -0,0 +1,98 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// pubFnRegexp matches a `pub fn` item declaration, capturing its name;
+// async/unsafe/const qualifiers appear between "pub" and "fn" in valid
+// Rust syntax (e.g. "pub async fn"), so they're tolerated but not required.
+var pubFnRegexp = regexp.MustCompile(`pub\s+(?:async\s+|unsafe\s+|const\s+)*fn\s+(\w+)`)
+
+// fnRegexp matches any fn item declaration, public or private, capturing
+// its name.
+var fnRegexp = regexp.MustCompile(`\bfn\s+(\w+)`)
+
+// UncoveredFunction is a function llvm-cov recorded as never executed
+// during the profiled test run.
+type UncoveredFunction struct {
+	File string
+	Name string
+	Line int
+}
+
+// UncoveredPublicFunctions scans repoRoot/file's source for `pub fn`
+// declarations and cross-references each against report's per-function
+// execution data, returning every one llvm-cov recorded as never executed.
+func UncoveredPublicFunctions(report *Report, repoRoot, file string) ([]UncoveredFunction, error) {
+	return uncoveredFunctions(report, repoRoot, file, pubFnRegexp)
+}
+
+// UncoveredFunctions is like UncoveredPublicFunctions but considers every
+// fn item in file, public or private, for callers (e.g. check run
+// annotations) that want full-file coverage context rather than only
+// gaps in the public API.
+func UncoveredFunctions(report *Report, repoRoot, file string) ([]UncoveredFunction, error) {
+	return uncoveredFunctions(report, repoRoot, file, fnRegexp)
+}
+
+// uncoveredFunctions scans repoRoot/file's source for declarations
+// matching pattern (whose sole capture group is the function name) and
+// cross-references each against report's per-function execution data
+// (matched on the function's short name, since llvm-cov records
+// fully-qualified symbol paths, e.g. "storage::Device::read"), returning
+// every one llvm-cov recorded as never executed. Functions llvm-cov has no
+// data for at all (e.g. the crate wasn't part of the profiled run) are not
+// reported, since there is no evidence either way.
+func uncoveredFunctions(report *Report, repoRoot, file string, pattern *regexp.Regexp) ([]UncoveredFunction, error) {
+	data, err := os.ReadFile(filepath.Join(repoRoot, file))
+	if err != nil {
+		return nil, errors.Wrap(err, "read source file")
+	}
+
+	executed := make(map[string]bool)
+	for _, fn := range report.Functions {
+		if fn.File != file {
+			continue
+		}
+		name := shortFunctionName(fn.Name)
+		executed[name] = executed[name] || fn.Executed
+	}
+
+	content := string(data)
+	var uncovered []UncoveredFunction
+	for _, m := range pattern.FindAllStringSubmatchIndex(content, -1) {
+		name := content[m[2]:m[3]]
+		wasExecuted, known := executed[name]
+		if !known || wasExecuted {
+			continue
+		}
+		uncovered = append(uncovered, UncoveredFunction{
+			File: file,
+			Name: name,
+			Line: 1 + strings.Count(content[:m[0]], "\n"),
+		})
+	}
+
+	return uncovered, nil
+}
+
+// shortFunctionName returns the last "::"-delimited segment of a
+// fully-qualified Rust symbol path, e.g. "storage::device::Device::read"
+// -> "read", so it can be matched against a plain `pub fn` name parsed
+// from source.
+func shortFunctionName(name string) string {
+	if i := strings.LastIndex(name, "::"); i >= 0 {
+		return name[i+2:]
+	}
+	return name
+}
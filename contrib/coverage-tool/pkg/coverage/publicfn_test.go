@@ -0,0 +1,72 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUncoveredPublicFunctions(t *testing.T) {
+	dir := t.TempDir()
+	src := "pub fn covered() {}\n\npub fn uncovered() {}\n\nfn private() {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "lib.rs"), []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	report := &Report{Functions: []FunctionCoverage{
+		{Name: "crate::covered", File: "lib.rs", Executed: true},
+		{Name: "crate::uncovered", File: "lib.rs", Executed: false},
+	}}
+
+	uncovered, err := UncoveredPublicFunctions(report, dir, "lib.rs")
+	if err != nil {
+		t.Fatalf("UncoveredPublicFunctions returned error: %v", err)
+	}
+	if len(uncovered) != 1 || uncovered[0].Name != "uncovered" {
+		t.Fatalf("uncovered = %+v, want just \"uncovered\"", uncovered)
+	}
+	if uncovered[0].Line != 3 {
+		t.Errorf("Line = %d, want 3", uncovered[0].Line)
+	}
+}
+
+func TestUncoveredFunctions(t *testing.T) {
+	dir := t.TempDir()
+	src := "pub fn covered() {}\n\nfn uncovered_private() {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "lib.rs"), []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	report := &Report{Functions: []FunctionCoverage{
+		{Name: "crate::covered", File: "lib.rs", Executed: true},
+		{Name: "crate::uncovered_private", File: "lib.rs", Executed: false},
+	}}
+
+	uncovered, err := UncoveredFunctions(report, dir, "lib.rs")
+	if err != nil {
+		t.Fatalf("UncoveredFunctions returned error: %v", err)
+	}
+	if len(uncovered) != 1 || uncovered[0].Name != "uncovered_private" {
+		t.Fatalf("uncovered = %+v, want just \"uncovered_private\"", uncovered)
+	}
+}
+
+func TestUncoveredPublicFunctionsSkipsUnknown(t *testing.T) {
+	dir := t.TempDir()
+	src := "pub fn not_profiled() {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "lib.rs"), []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	uncovered, err := UncoveredPublicFunctions(&Report{}, dir, "lib.rs")
+	if err != nil {
+		t.Fatalf("UncoveredPublicFunctions returned error: %v", err)
+	}
+	if len(uncovered) != 0 {
+		t.Errorf("uncovered = %+v, want none for a function llvm-cov has no data for", uncovered)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package embed ranks candidate module-context files by their relevance
+// to a target file, so cmd/generate.go can include only the most useful
+// few within the token budget instead of dumping every sibling file into
+// the prompt.
+package embed
+
+import (
+	"math"
+	"sort"
+)
+
+// Embedder computes an embedding vector for a batch of texts, one vector
+// per text in the same order.
+type Embedder interface {
+	Embed(texts []string) ([][]float64, error)
+}
+
+// Candidate is one file being ranked for relevance to a target.
+type Candidate struct {
+	Path    string
+	Content string
+}
+
+// Rank scores each of candidates against target using embedder, and
+// returns their paths sorted most-relevant first.
+func Rank(embedder Embedder, target string, candidates []Candidate) ([]string, error) {
+	texts := make([]string, 0, len(candidates)+1)
+	texts = append(texts, target)
+	for _, c := range candidates {
+		texts = append(texts, c.Content)
+	}
+
+	vectors, err := embedder.Embed(texts)
+	if err != nil {
+		return nil, err
+	}
+	targetVector, vectors := vectors[0], vectors[1:]
+
+	type scored struct {
+		path  string
+		score float64
+	}
+	ranked := make([]scored, len(candidates))
+	for i, c := range candidates {
+		ranked[i] = scored{path: c.Path, score: cosine(targetVector, vectors[i])}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	paths := make([]string, len(ranked))
+	for i, r := range ranked {
+		paths[i] = r.path
+	}
+	return paths, nil
+}
+
+// cosine returns the cosine similarity between a and b, or 0 if either is
+// the zero vector or they differ in length.
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
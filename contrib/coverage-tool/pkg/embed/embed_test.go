@@ -0,0 +1,44 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package embed
+
+import "testing"
+
+type fakeEmbedder struct {
+	vectors map[string][]float64
+}
+
+func (f fakeEmbedder) Embed(texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, t := range texts {
+		vectors[i] = f.vectors[t]
+	}
+	return vectors, nil
+}
+
+func TestRankOrdersMostSimilarFirst(t *testing.T) {
+	embedder := fakeEmbedder{vectors: map[string][]float64{
+		"target":  {1, 0, 0},
+		"close":   {0.9, 0.1, 0},
+		"distant": {0, 0, 1},
+	}}
+
+	ranked, err := Rank(embedder, "target", []Candidate{
+		{Path: "distant.rs", Content: "distant"},
+		{Path: "close.rs", Content: "close"},
+	})
+	if err != nil {
+		t.Fatalf("Rank returned error: %v", err)
+	}
+	if len(ranked) != 2 || ranked[0] != "close.rs" || ranked[1] != "distant.rs" {
+		t.Errorf("Rank() = %v, want [close.rs distant.rs]", ranked)
+	}
+}
+
+func TestCosineZeroVectorIsZero(t *testing.T) {
+	if got := cosine([]float64{0, 0}, []float64{1, 1}); got != 0 {
+		t.Errorf("cosine(zero, v) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package embed
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strings"
+)
+
+// wordPattern splits source text into identifier-like tokens for Local's
+// bag-of-words embedding.
+var wordPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// Local is a dependency-free Embedder that hashes token frequencies into a
+// fixed-size vector. It gives coarser rankings than a real embedding
+// model, but needs no network access, making it the default for
+// air-gapped runs.
+type Local struct {
+	// Dimensions is the vector size to hash tokens into. 0 uses 256.
+	Dimensions int
+}
+
+// Embed returns one hashed bag-of-words vector per text in texts.
+func (l Local) Embed(texts []string) ([][]float64, error) {
+	dims := l.Dimensions
+	if dims == 0 {
+		dims = 256
+	}
+
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vector := make([]float64, dims)
+		for _, word := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(word))
+			vector[int(h.Sum32()%uint32(dims))]++
+		}
+		vectors[i] = vector
+	}
+	return vectors, nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package embed
+
+import "testing"
+
+func TestLocalEmbedRanksSharedVocabularyHigher(t *testing.T) {
+	local := Local{}
+	ranked, err := Rank(local, "fn parse_device(id: u64) -> Device", []Candidate{
+		{Path: "unrelated.rs", Content: "fn render_widget(color: Color) -> Widget"},
+		{Path: "device.rs", Content: "fn parse_device(id: u64) -> Option<Device>"},
+	})
+	if err != nil {
+		t.Fatalf("Rank returned error: %v", err)
+	}
+	if len(ranked) != 2 || ranked[0] != "device.rs" {
+		t.Errorf("Rank() = %v, want device.rs ranked first", ranked)
+	}
+}
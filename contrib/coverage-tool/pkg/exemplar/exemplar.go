@@ -0,0 +1,101 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package exemplar finds well-tested sibling files in a target file's crate
+// to use as few-shot style examples in a generation prompt, so generated
+// tests follow the crate's existing testing conventions instead of a style
+// the model invents on its own.
+package exemplar
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+// minCoveragePercent is the line-coverage threshold a sibling file must
+// clear to be considered "well-tested" enough to exemplify.
+const minCoveragePercent = 80.0
+
+// testModuleMarker is the substring a candidate file must contain to prove
+// it actually has hand-written tests, not just incidental coverage from
+// integration tests elsewhere in the workspace.
+const testModuleMarker = "#[cfg(test)]"
+
+// Example is a single style exemplar to include in a generation prompt.
+type Example struct {
+	// Path is the exemplar file's path relative to repoRoot.
+	Path string
+	// Content is the exemplar file's full source, including its existing
+	// #[cfg(test)] module.
+	Content string
+}
+
+// Find returns up to max exemplars for target: the most highly covered
+// files in target's crate (the nearest ancestor directory containing a
+// Cargo.toml) that already have a #[cfg(test)] module, ranked by coverage
+// percent descending. It returns nil if target's crate can't be found, no
+// candidate clears minCoveragePercent, or max is 0.
+func Find(repoRoot, target string, files []coverage.FileStats, max int) []Example {
+	if max <= 0 {
+		return nil
+	}
+
+	crateDir := findCrateRoot(repoRoot, target)
+	if crateDir == "" {
+		return nil
+	}
+
+	candidates := make([]coverage.FileStats, 0, len(files))
+	for _, f := range files {
+		if f.Path == target || f.Percent() < minCoveragePercent {
+			continue
+		}
+		if crateDir != "." && !strings.HasPrefix(f.Path, crateDir+"/") {
+			continue
+		}
+		candidates = append(candidates, f)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Percent() > candidates[j].Percent() })
+
+	var examples []Example
+	for _, f := range candidates {
+		if len(examples) >= max {
+			break
+		}
+
+		data, err := os.ReadFile(filepath.Join(repoRoot, f.Path))
+		if err != nil || !strings.Contains(string(data), testModuleMarker) {
+			continue
+		}
+		examples = append(examples, Example{Path: f.Path, Content: string(data)})
+	}
+
+	return examples
+}
+
+// findCrateRoot walks up from file's directory looking for the nearest
+// Cargo.toml, returning its directory relative to repoRoot, or "" if none
+// is found.
+func findCrateRoot(repoRoot, file string) string {
+	dir := filepath.Dir(filepath.Join(repoRoot, file))
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "Cargo.toml")); err == nil {
+			rel, err := filepath.Rel(repoRoot, dir)
+			if err != nil {
+				return dir
+			}
+			return rel
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exemplar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+func writeCrate(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "Cargo.toml"), []byte("[package]\nname = \"storage\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile Cargo.toml returned error: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile %s returned error: %v", name, err)
+		}
+	}
+	return root
+}
+
+func TestFindRanksByCoverageAndRequiresTestModule(t *testing.T) {
+	root := writeCrate(t, map[string]string{
+		"target.rs":   "pub fn f() {}",
+		"good.rs":     "pub fn g() {}\n\n#[cfg(test)]\nmod tests {}\n",
+		"better.rs":   "pub fn h() {}\n\n#[cfg(test)]\nmod tests {}\n",
+		"untested.rs": "pub fn i() {}\n",
+	})
+
+	files := []coverage.FileStats{
+		{Path: "target.rs", LinesCovered: 0, LinesTotal: 10},
+		{Path: "good.rs", LinesCovered: 85, LinesTotal: 100},
+		{Path: "better.rs", LinesCovered: 95, LinesTotal: 100},
+		{Path: "untested.rs", LinesCovered: 100, LinesTotal: 100},
+	}
+
+	examples := Find(root, "target.rs", files, 2)
+
+	if len(examples) != 2 {
+		t.Fatalf("Find() returned %d examples, want 2", len(examples))
+	}
+	if examples[0].Path != "better.rs" || examples[1].Path != "good.rs" {
+		t.Errorf("Find() = %v, want better.rs then good.rs", examples)
+	}
+}
+
+func TestFindExcludesLowCoverageAndReturnsNoneWithoutCrate(t *testing.T) {
+	root := t.TempDir()
+	files := []coverage.FileStats{{Path: "orphan.rs", LinesCovered: 100, LinesTotal: 100}}
+
+	if got := Find(root, "orphan.rs", files, 2); got != nil {
+		t.Errorf("Find() = %v, want nil when no Cargo.toml is found", got)
+	}
+}
+
+func TestFindReturnsNilWhenMaxIsZero(t *testing.T) {
+	root := writeCrate(t, map[string]string{"good.rs": "#[cfg(test)]\nmod tests {}\n"})
+	files := []coverage.FileStats{{Path: "good.rs", LinesCovered: 100, LinesTotal: 100}}
+
+	if got := Find(root, "target.rs", files, 0); got != nil {
+		t.Errorf("Find() = %v, want nil when max is 0", got)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package existingtests finds the tests already present in a target file's
+// #[cfg(test)] module, so cmd/generate.go can tell the model not to
+// regenerate them and drop any generated test that collides with one by
+// name.
+package existingtests
+
+import (
+	"strings"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/chunk"
+)
+
+// testModuleMarker is the substring content must contain for it to have a
+// #[cfg(test)] module worth extracting from.
+const testModuleMarker = "#[cfg(test)]"
+
+// Test is a single #[test] function already defined in a file's
+// #[cfg(test)] module.
+type Test struct {
+	Name string
+	Body string
+}
+
+// Extract returns the tests already defined in content's #[cfg(test)]
+// module, in source order, or nil if content has none. It assumes the
+// module is the last thing in the file, which holds for every backend this
+// package generates prompts for; anything after content's first
+// #[cfg(test)] marker is treated as belonging to it.
+func Extract(content string) []Test {
+	idx := strings.Index(content, testModuleMarker)
+	if idx == -1 {
+		return nil
+	}
+
+	functions := chunk.Split(content[idx:])
+	tests := make([]Test, len(functions))
+	for i, fn := range functions {
+		tests[i] = Test{Name: fn.Name, Body: fn.Body}
+	}
+	return tests
+}
+
+// Filter removes any function from generated whose name collides with one
+// of existing's, along with any attribute or blank lines directly
+// preceding it, and returns the filtered text plus the names it dropped.
+func Filter(generated string, existing []Test) (string, []string) {
+	if len(existing) == 0 {
+		return generated, nil
+	}
+
+	collides := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		collides[t.Name] = true
+	}
+
+	var dropped []string
+	filtered := generated
+	for _, fn := range chunk.Split(generated) {
+		if !collides[fn.Name] {
+			continue
+		}
+		filtered = removeFunction(filtered, fn.Body)
+		dropped = append(dropped, fn.Name)
+	}
+	return filtered, dropped
+}
+
+// removeFunction deletes body's lines from source, along with any
+// attribute (#[...]) or blank lines directly preceding it, so filtering a
+// colliding test doesn't leave an orphaned #[test] attribute behind.
+func removeFunction(source, body string) string {
+	idx := strings.Index(source, body)
+	if idx == -1 {
+		return source
+	}
+
+	before := strings.Split(source[:idx], "\n")
+	before = before[:len(before)-1] // drop the partial line leading up to body, always ""
+	start := len(before)
+	for start > 0 && isAttributeOrBlank(before[start-1]) {
+		start--
+	}
+
+	after := strings.TrimPrefix(source[idx+len(body):], "\n")
+	if start == 0 {
+		return after
+	}
+	return strings.Join(before[:start], "\n") + "\n" + after
+}
+
+func isAttributeOrBlank(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#[")
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package existingtests
+
+import (
+	"strings"
+	"testing"
+)
+
+const source = `pub fn add(a: i32, b: i32) -> i32 {
+    a + b
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_add_positive() {
+        assert_eq!(add(1, 2), 3);
+    }
+
+    #[test]
+    fn test_add_negative() {
+        assert_eq!(add(-1, -2), -3);
+    }
+}
+`
+
+func TestExtractFindsEveryTest(t *testing.T) {
+	tests := Extract(source)
+	if len(tests) != 2 {
+		t.Fatalf("Extract() returned %d tests, want 2", len(tests))
+	}
+	if tests[0].Name != "test_add_positive" || tests[1].Name != "test_add_negative" {
+		t.Errorf("test names = %q, %q; want test_add_positive, test_add_negative", tests[0].Name, tests[1].Name)
+	}
+}
+
+func TestExtractReturnsNilWithoutTestModule(t *testing.T) {
+	if tests := Extract("pub fn add(a: i32, b: i32) -> i32 { a + b }"); tests != nil {
+		t.Errorf("Extract() = %v, want nil", tests)
+	}
+}
+
+func TestFilterDropsCollidingTestsByName(t *testing.T) {
+	existing := Extract(source)
+	generated := `#[test]
+fn test_add_positive() {
+    assert_eq!(add(1, 2), 3);
+}
+
+#[test]
+fn test_add_zero() {
+    assert_eq!(add(0, 0), 0);
+}
+`
+
+	filtered, dropped := Filter(generated, existing)
+	if len(dropped) != 1 || dropped[0] != "test_add_positive" {
+		t.Fatalf("Filter() dropped = %v, want [test_add_positive]", dropped)
+	}
+	if strings.Contains(filtered, "test_add_positive") {
+		t.Errorf("Filter() left the colliding test in place: %q", filtered)
+	}
+	if !strings.Contains(filtered, "test_add_zero") {
+		t.Errorf("Filter() removed a non-colliding test: %q", filtered)
+	}
+	if strings.Contains(filtered, "#[test]\n\n#[test]") {
+		t.Errorf("Filter() left an orphaned #[test] attribute behind: %q", filtered)
+	}
+}
+
+func TestFilterLeavesGeneratedUnchangedWithoutExistingTests(t *testing.T) {
+	generated := "#[test]\nfn test_add_zero() {}\n"
+	filtered, dropped := Filter(generated, nil)
+	if filtered != generated || dropped != nil {
+		t.Errorf("Filter() = %q, %v; want the input unchanged and no drops", filtered, dropped)
+	}
+}
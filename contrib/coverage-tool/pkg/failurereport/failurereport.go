@@ -0,0 +1,115 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package failurereport renders a history.Store's rejected generation
+// attempts as a classified markdown report, so maintainers can see which
+// failure modes (compile errors, failing tests, flaky tests, or
+// insufficient coverage delta) are most common and use that to steer
+// prompt changes.
+package failurereport
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+// Classification buckets a rejected attempt's history.Attempt.Reason into
+// one of the failure modes candidate.Evaluator can reject a generated
+// test for.
+const (
+	CompileError      = "compile error"
+	TestFailure       = "test failure"
+	Flaky             = "flaky"
+	InsufficientDelta = "insufficient delta"
+	Other             = "other"
+)
+
+// classifications lists every Classification in the order Markdown's
+// summary table renders them.
+var classifications = []string{CompileError, TestFailure, Flaky, InsufficientDelta, Other}
+
+// Classify buckets reason (a history.Attempt.Reason, as set by
+// candidate.Evaluator) into one of the Classification constants, by
+// matching the substrings Evaluator's own failure messages use.
+func Classify(reason string) string {
+	switch {
+	case containsAny(reason, "bisection failed", "bisection gave up"):
+		return Flaky
+	case containsAny(reason, "cargo check failed", "rustfmt failed", "cross-target check failed", "toolchain check failed", "clippy failed"):
+		return CompileError
+	case containsAny(reason, "validation failed", "smoke test failed", "miri failed"):
+		return TestFailure
+	case containsAny(reason, "below --min-delta", "below --min-mutation-score", "coverage measurement failed", "cargo-mutants failed"):
+		return InsufficientDelta
+	default:
+		return Other
+	}
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// Markdown renders every rejected (Success == false) attempt in attempts
+// as a classification summary followed by a per-attempt table.
+func Markdown(attempts []history.Attempt) string {
+	var failures []history.Attempt
+	counts := make(map[string]int, len(classifications))
+	files := make(map[string]struct{})
+	for _, a := range attempts {
+		if a.Success {
+			continue
+		}
+		failures = append(failures, a)
+		counts[Classify(a.Reason)]++
+		files[a.File] = struct{}{}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Rejected candidates\n\n")
+
+	if len(failures) == 0 {
+		fmt.Fprintf(&buf, "No rejected generation attempts recorded.\n")
+		return buf.String()
+	}
+
+	fmt.Fprintf(&buf, "%d rejected attempt(s) across %d file(s).\n\n", len(failures), len(files))
+
+	fmt.Fprintf(&buf, "| Classification | Count |\n|---|---|\n")
+	for _, c := range classifications {
+		if counts[c] > 0 {
+			fmt.Fprintf(&buf, "| %s | %d |\n", c, counts[c])
+		}
+	}
+
+	fmt.Fprintf(&buf, "\n| Time | File | Classification | Reason |\n|---|---|---|---|\n")
+	for _, a := range failures {
+		fmt.Fprintf(&buf, "| %s | %s | %s | %s |\n", a.Timestamp.Format("2006-01-02 15:04"), a.File, Classify(a.Reason), escapeTableCell(a.Reason))
+	}
+
+	return buf.String()
+}
+
+// escapeTableCell escapes characters that would otherwise break a
+// markdown table cell.
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// WriteMarkdown renders attempts and writes the result to path.
+func WriteMarkdown(path string, attempts []history.Attempt) error {
+	return errors.Wrap(os.WriteFile(path, []byte(Markdown(attempts)), 0o644), "write failures report")
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package failurereport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+func TestClassifyBucketsKnownReasons(t *testing.T) {
+	cases := map[string]string{
+		"cargo check failed: exit status 1":                         CompileError,
+		"rustfmt failed: exit status 1":                             CompileError,
+		"clippy failed: exit status 1":                              CompileError,
+		"validation failed, failing test(s): it_works":              TestFailure,
+		"smoke test failed: exit status 1":                          TestFailure,
+		"bisection gave up after 3 attempt(s), still failing: a, b": Flaky,
+		"coverage improved by only 0.50%, below --min-delta 2.00%":  InsufficientDelta,
+		"mutation score 0.10 is below --min-mutation-score 0.50":    InsufficientDelta,
+		"denylisted pattern(s) in generated test: #[ignore]":        Other,
+		"": Other,
+	}
+
+	for reason, want := range cases {
+		if got := Classify(reason); got != want {
+			t.Errorf("Classify(%q) = %q, want %q", reason, got, want)
+		}
+	}
+}
+
+func TestMarkdownReportsNoFailures(t *testing.T) {
+	md := Markdown([]history.Attempt{{File: "a.rs", Success: true}})
+	if !strings.Contains(md, "No rejected generation attempts recorded.") {
+		t.Errorf("Markdown() = %q, want the no-failures message", md)
+	}
+}
+
+func TestMarkdownSummarizesAndListsFailures(t *testing.T) {
+	attempts := []history.Attempt{
+		{File: "storage/src/device.rs", Success: false, Reason: "cargo check failed: exit status 1"},
+		{File: "storage/src/device.rs", Success: true},
+		{File: "rafs/src/mock.rs", Success: false, Reason: "coverage improved by only 0.50%, below --min-delta 2.00%"},
+	}
+
+	md := Markdown(attempts)
+
+	if want := "2 rejected attempt(s) across 2 file(s)."; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+	if want := "| compile error | 1 |"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+	if want := "| insufficient delta | 1 |"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+	if strings.Contains(md, "storage/src/device.rs |") == false {
+		t.Errorf("Markdown() = %q, want the failing attempt's file listed", md)
+	}
+}
+
+func TestEscapeTableCellNeutralizesPipesAndNewlines(t *testing.T) {
+	if got := escapeTableCell("a | b\nc"); got != `a \| b c` {
+		t.Errorf("escapeTableCell() = %q, want %q", got, `a \| b c`)
+	}
+}
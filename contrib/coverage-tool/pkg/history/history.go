@@ -0,0 +1,134 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package history persists per-file generation outcomes across coverage-tool
+// runs, so scheduled automation can make decisions informed by past attempts
+// instead of treating every run as a clean slate.
+package history
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Attempt records the outcome of a single generation attempt for a file.
+type Attempt struct {
+	File      string    `json:"file"`
+	Timestamp time.Time `json:"timestamp"`
+	Success   bool      `json:"success"`
+	Reason    string    `json:"reason,omitempty"`
+	// PromptTokens, CompletionTokens, and CostUSD record the LLM usage this
+	// attempt spent, if the caller tracked it, so a later report can render
+	// an expenses breakdown across every attempt rather than only the
+	// accepted one.
+	PromptTokens     int     `json:"promptTokens,omitempty"`
+	CompletionTokens int     `json:"completionTokens,omitempty"`
+	CostUSD          float64 `json:"costUsd,omitempty"`
+}
+
+// Store is an append-only, file-backed log of generation attempts.
+type Store struct {
+	path     string
+	Attempts []Attempt `json:"attempts"`
+}
+
+// Open loads the history store at path, creating an empty one if it does
+// not yet exist.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read history store")
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrap(err, "parse history store")
+	}
+	s.path = path
+
+	return s, nil
+}
+
+// Record appends a new attempt outcome and persists the store to disk.
+// promptTokens, completionTokens, and costUSD record the LLM usage this
+// attempt spent; pass zeros when the caller has none to report (e.g. an
+// attempt that failed before calling the provider).
+func (s *Store) Record(file string, success bool, reason string, promptTokens, completionTokens int, costUSD float64) error {
+	s.Attempts = append(s.Attempts, Attempt{
+		File:             file,
+		Timestamp:        time.Now(),
+		Success:          success,
+		Reason:           reason,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		CostUSD:          costUSD,
+	})
+	return s.save()
+}
+
+func (s *Store) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return errors.Wrap(err, "create history directory")
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal history store")
+	}
+
+	return errors.Wrap(os.WriteFile(s.path, data, 0o644), "write history store")
+}
+
+// AttemptsFor returns every recorded attempt for file, oldest first.
+func (s *Store) AttemptsFor(file string) []Attempt {
+	var attempts []Attempt
+	for _, a := range s.Attempts {
+		if a.File == file {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts
+}
+
+// LastSuccess returns the timestamp of the most recent successful
+// generation attempt for file, and whether one exists at all.
+func (s *Store) LastSuccess(file string) (time.Time, bool) {
+	var last time.Time
+	found := false
+	for _, a := range s.AttemptsFor(file) {
+		if a.Success && (!found || a.Timestamp.After(last)) {
+			last = a.Timestamp
+			found = true
+		}
+	}
+	return last, found
+}
+
+// InCooldown reports whether file should be skipped because its last
+// maxFailures generation attempts all failed within the given cooldown
+// period. Once the cooldown period has elapsed since the most recent
+// attempt, the file becomes eligible for selection again.
+func (s *Store) InCooldown(file string, maxFailures int, cooldown time.Duration) bool {
+	attempts := s.AttemptsFor(file)
+	if len(attempts) < maxFailures {
+		return false
+	}
+
+	recent := attempts[len(attempts)-maxFailures:]
+	for _, a := range recent {
+		if a.Success {
+			return false
+		}
+	}
+
+	last := attempts[len(attempts)-1]
+	return time.Since(last.Timestamp) < cooldown
+}
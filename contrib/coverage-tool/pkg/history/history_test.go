@@ -0,0 +1,60 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInCooldownAfterRepeatedFailures(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.Record("storage/src/device.rs", false, "compile error", 0, 0, 0); err != nil {
+			t.Fatalf("Record returned error: %v", err)
+		}
+	}
+
+	if !s.InCooldown("storage/src/device.rs", 3, time.Hour) {
+		t.Error("expected file to be in cooldown after 3 consecutive failures")
+	}
+	if s.InCooldown("storage/src/other.rs", 3, time.Hour) {
+		t.Error("unrelated file should not be in cooldown")
+	}
+}
+
+func TestInCooldownClearsAfterSuccess(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	_ = s.Record("storage/src/device.rs", false, "compile error", 0, 0, 0)
+	_ = s.Record("storage/src/device.rs", false, "compile error", 0, 0, 0)
+	_ = s.Record("storage/src/device.rs", true, "", 0, 0, 0)
+
+	if s.InCooldown("storage/src/device.rs", 2, time.Hour) {
+		t.Error("a recent success should clear the cooldown")
+	}
+}
+
+func TestInCooldownExpires(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	_ = s.Record("storage/src/device.rs", false, "compile error", 0, 0, 0)
+	_ = s.Record("storage/src/device.rs", false, "compile error", 0, 0, 0)
+
+	if s.InCooldown("storage/src/device.rs", 2, 0) {
+		t.Error("a zero-length cooldown should never hold a file back")
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package historydb persists every coverage-tool run's outcome into a
+// SQLite database, so a weekly coverage review can query coverage over
+// time, which improvements were accepted, which models were used, and
+// what they cost, without replaying JSON logs.
+package historydb
+
+import (
+	"database/sql"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+// Run records a single generate/report run's outcome for a target file.
+type Run struct {
+	Timestamp        time.Time
+	File             string
+	Model            string
+	BeforePercent    float64
+	AfterPercent     float64
+	Accepted         bool
+	PromptTokens     int
+	CompletionTokens int
+	CostUSD          float64
+}
+
+// DB is a SQLite-backed log of coverage-tool runs.
+type DB struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// ensures its schema exists.
+func Open(path string) (*DB, error) {
+	sqlDB, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open history database")
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp         DATETIME NOT NULL,
+	file              TEXT NOT NULL,
+	model             TEXT NOT NULL,
+	before_percent    REAL NOT NULL,
+	after_percent     REAL NOT NULL,
+	accepted          BOOLEAN NOT NULL,
+	prompt_tokens     INTEGER NOT NULL,
+	completion_tokens INTEGER NOT NULL,
+	cost_usd          REAL NOT NULL
+)`
+	if _, err := sqlDB.Exec(schema); err != nil {
+		sqlDB.Close()
+		return nil, errors.Wrap(err, "create runs table")
+	}
+
+	return &DB{db: sqlDB}, nil
+}
+
+// Close releases the underlying database connection.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// Record inserts a new run.
+func (d *DB) Record(run Run) error {
+	_, err := d.db.Exec(
+		`INSERT INTO runs (timestamp, file, model, before_percent, after_percent, accepted, prompt_tokens, completion_tokens, cost_usd)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.Timestamp, run.File, run.Model, run.BeforePercent, run.AfterPercent, run.Accepted,
+		run.PromptTokens, run.CompletionTokens, run.CostUSD,
+	)
+	return errors.Wrap(err, "insert run")
+}
+
+// Runs returns every recorded run, oldest first.
+func (d *DB) Runs() ([]Run, error) {
+	rows, err := d.db.Query(
+		`SELECT timestamp, file, model, before_percent, after_percent, accepted, prompt_tokens, completion_tokens, cost_usd
+		 FROM runs ORDER BY timestamp ASC`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "query runs")
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var r Run
+		if err := rows.Scan(&r.Timestamp, &r.File, &r.Model, &r.BeforePercent, &r.AfterPercent, &r.Accepted,
+			&r.PromptTokens, &r.CompletionTokens, &r.CostUSD); err != nil {
+			return nil, errors.Wrap(err, "scan run")
+		}
+		runs = append(runs, r)
+	}
+	return runs, errors.Wrap(rows.Err(), "iterate runs")
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package historydb
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordAndRuns(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer db.Close()
+
+	run := Run{
+		Timestamp:     time.Now().UTC().Truncate(time.Second),
+		File:          "storage/src/device.rs",
+		Model:         "gpt-4o-mini",
+		BeforePercent: 40,
+		AfterPercent:  75,
+		Accepted:      true,
+		CostUSD:       0.05,
+	}
+	if err := db.Record(run); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+
+	runs, err := db.Runs()
+	if err != nil {
+		t.Fatalf("Runs returned error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].File != run.File || runs[0].AfterPercent != run.AfterPercent {
+		t.Fatalf("Runs() = %+v, want a single run matching %+v", runs, run)
+	}
+}
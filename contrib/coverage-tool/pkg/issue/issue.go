@@ -0,0 +1,190 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package issue fetches GitHub issues so their description can be used as
+// generation context, bridging bug reports and the test suite.
+package issue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Issue is the subset of the GitHub issue API response coverage-tool
+// needs as model context.
+type Issue struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+var issueURLPattern = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+)/issues/(\d+)$`)
+
+// doGithubRequest sends a GitHub REST API request with the Accept and
+// (if GITHUB_TOKEN is set) Authorization headers every call in this
+// package needs, and decodes a successful JSON response into out. out
+// may be nil for calls that don't need the response body.
+func doGithubRequest(method, apiURL string, body io.Reader, out any) error {
+	req, err := http.NewRequest(method, apiURL, body)
+	if err != nil {
+		return errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s", method, apiURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return errors.Errorf("GitHub API returned status %d for %s %s", resp.StatusCode, method, apiURL)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return errors.Wrapf(json.NewDecoder(resp.Body).Decode(out), "decode response for %s %s", method, apiURL)
+}
+
+// bodyContainsMarker reports whether body contains marker as a literal
+// substring, not a regular expression.
+func bodyContainsMarker(body, marker string) bool {
+	return strings.Contains(body, marker)
+}
+
+// listedIssue is the subset of the GitHub list-issues API response needed
+// to find a pinned issue by marker.
+type listedIssue struct {
+	Number int    `json:"number"`
+	Body   string `json:"body"`
+}
+
+// FindPinned searches open issues in owner/repo for one whose body
+// contains marker, returning its issue number if found. Only the first
+// 100 open issues are searched.
+func FindPinned(owner, repo, marker string) (int, bool, error) {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues?state=open&per_page=100", owner, repo)
+
+	var issues []listedIssue
+	if err := doGithubRequest(http.MethodGet, apiURL, nil, &issues); err != nil {
+		return 0, false, errors.Wrap(err, "list issues")
+	}
+
+	for _, i := range issues {
+		if bodyContainsMarker(i.Body, marker) {
+			return i.Number, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// listedComment is the subset of the GitHub issue-comment API response
+// needed to find a comment by marker.
+type listedComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// UpsertComment posts body as a comment on issue or PR number in
+// owner/repo, replacing any existing comment whose body contains marker
+// instead of stacking a new one, since pull requests and issues share a
+// comments API.
+func UpsertComment(owner, repo string, number int, body, marker string) error {
+	commentsURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d/comments", owner, repo, number)
+
+	existingID, found, err := findCommentByMarker(commentsURL, marker)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(struct {
+		Body string `json:"body"`
+	}{Body: body})
+	if err != nil {
+		return errors.Wrap(err, "marshal comment payload")
+	}
+
+	method, apiURL := http.MethodPost, commentsURL
+	if found {
+		method, apiURL = http.MethodPatch, fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/comments/%d", owner, repo, existingID)
+	}
+
+	return doGithubRequest(method, apiURL, bytes.NewReader(payload), nil)
+}
+
+// findCommentByMarker searches the first 100 comments at commentsURL for
+// one whose body contains marker, returning its comment ID if found.
+func findCommentByMarker(commentsURL, marker string) (int64, bool, error) {
+	var comments []listedComment
+	if err := doGithubRequest(http.MethodGet, commentsURL+"?per_page=100", nil, &comments); err != nil {
+		return 0, false, errors.Wrap(err, "list comments")
+	}
+
+	for _, c := range comments {
+		if bodyContainsMarker(c.Body, marker) {
+			return c.ID, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// CreateOrUpdate creates a new issue with title and body in owner/repo,
+// or, if an open issue already has marker in its body, replaces that
+// issue's body instead of creating a duplicate.
+func CreateOrUpdate(owner, repo, title, body, marker string) error {
+	number, found, err := FindPinned(owner, repo, marker)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(struct {
+		Title string `json:"title,omitempty"`
+		Body  string `json:"body"`
+	}{Title: title, Body: body})
+	if err != nil {
+		return errors.Wrap(err, "marshal issue payload")
+	}
+
+	method, apiURL := http.MethodPost, fmt.Sprintf("https://api.github.com/repos/%s/%s/issues", owner, repo)
+	if found {
+		method, apiURL = http.MethodPatch, fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%d", owner, repo, number)
+	}
+
+	return doGithubRequest(method, apiURL, bytes.NewReader(payload), nil)
+}
+
+// Fetch retrieves the title and body of the GitHub issue at url, which
+// must look like https://github.com/<owner>/<repo>/issues/<number>.
+func Fetch(url string) (*Issue, error) {
+	m := issueURLPattern.FindStringSubmatch(url)
+	if m == nil {
+		return nil, errors.Errorf("%q is not a GitHub issue URL", url)
+	}
+	owner, repo, number := m[1], m[2], m[3]
+
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%s", owner, repo, number)
+
+	var result Issue
+	if err := doGithubRequest(http.MethodGet, apiURL, nil, &result); err != nil {
+		return nil, errors.Wrap(err, "fetch issue")
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package issue
+
+import "testing"
+
+func TestFetchRejectsNonIssueURL(t *testing.T) {
+	if _, err := Fetch("https://github.com/bergwolf/nydus/pull/1"); err == nil {
+		t.Error("Fetch should reject a non-issue URL")
+	}
+}
+
+func TestBodyContainsMarker(t *testing.T) {
+	if !bodyContainsMarker("status: pinned-report", "pinned-report") {
+		t.Error("bodyContainsMarker should find a marker present in the body")
+	}
+	if bodyContainsMarker("status: unrelated", "pinned-report") {
+		t.Error("bodyContainsMarker should not find a marker absent from the body")
+	}
+	if bodyContainsMarker("a.b.c", "a.b.c") == bodyContainsMarker("axbxc", "a.b.c") {
+		t.Error("bodyContainsMarker should treat marker as a literal string, not a regular expression")
+	}
+}
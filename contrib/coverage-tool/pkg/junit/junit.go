@@ -0,0 +1,79 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package junit renders a coverage-tool run's stages as a JUnit XML
+// report, so CI systems like Jenkins that already parse JUnit results can
+// render a coverage-improvement run as structured test results without a
+// dedicated plugin.
+package junit
+
+import (
+	"encoding/xml"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// TestCase is a single stage or attempt within a Suite. An empty Failure
+// means the case passed.
+type TestCase struct {
+	Name    string
+	Failure string
+}
+
+// Suite is a named group of TestCases, e.g. one coverage-tool run.
+type Suite struct {
+	Name  string
+	Cases []TestCase
+}
+
+// xmlFailure is the JUnit XML wire shape for a failed test case.
+type xmlFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// xmlTestCase is the JUnit XML wire shape for a single test case.
+type xmlTestCase struct {
+	Name    string      `xml:"name,attr"`
+	Failure *xmlFailure `xml:"failure,omitempty"`
+}
+
+// xmlTestSuite is the JUnit XML wire shape for a suite of test cases.
+type xmlTestSuite struct {
+	Name      string        `xml:"name,attr"`
+	Tests     int           `xml:"tests,attr"`
+	Failures  int           `xml:"failures,attr"`
+	TestCases []xmlTestCase `xml:"testcase"`
+}
+
+// xmlTestSuites is the JUnit XML wire shape for the report's root element.
+type xmlTestSuites struct {
+	XMLName xml.Name       `xml:"testsuites"`
+	Suites  []xmlTestSuite `xml:"testsuite"`
+}
+
+// Write renders suites as a JUnit XML report and writes it to path.
+func Write(path string, suites []Suite) error {
+	root := xmlTestSuites{Suites: make([]xmlTestSuite, len(suites))}
+	for i, suite := range suites {
+		cases := make([]xmlTestCase, len(suite.Cases))
+		failures := 0
+		for j, c := range suite.Cases {
+			cases[j] = xmlTestCase{Name: c.Name}
+			if c.Failure != "" {
+				cases[j].Failure = &xmlFailure{Message: c.Failure}
+				failures++
+			}
+		}
+		root.Suites[i] = xmlTestSuite{Name: suite.Name, Tests: len(cases), Failures: failures, TestCases: cases}
+	}
+
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal junit report")
+	}
+	data = append([]byte(xml.Header), data...)
+
+	return errors.Wrap(os.WriteFile(path, data, 0o644), "write junit report")
+}
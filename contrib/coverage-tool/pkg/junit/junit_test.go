@@ -0,0 +1,40 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package junit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	suites := []Suite{
+		{
+			Name: "coverage-tool",
+			Cases: []TestCase{
+				{Name: "analyze"},
+				{Name: "validate#1", Failure: "cargo test exited 1"},
+			},
+		},
+	}
+
+	if err := Write(path, suites); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	for _, want := range []string{`tests="2"`, `failures="1"`, `name="analyze"`, `message="cargo test exited 1"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("junit output = %s, want it to contain %q", data, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lint scans generated test code for network access, filesystem
+// escapes, long sleeps, and vacuous assertions before it is ever compiled
+// or run, so an obviously unsafe, slow, or tautological candidate is
+// rejected in microseconds instead of burning a full validation cycle
+// on it.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSleep is the longest std::thread::sleep duration Check allows before
+// flagging it as a long sleep.
+const MaxSleep = 5 * time.Second
+
+var (
+	networkPattern       = regexp.MustCompile(`\bstd::net::\w+|\breqwest::\w+`)
+	absolutePathPattern  = regexp.MustCompile(`"(/[^"]*)"`)
+	sleepSecsPattern     = regexp.MustCompile(`from_secs\((\d+)\)`)
+	sleepMillisPattern   = regexp.MustCompile(`from_millis\((\d+)\)`)
+	statementPattern     = regexp.MustCompile(`[^;{}]+;`)
+	assertPattern        = regexp.MustCompile(`\b(?:debug_)?assert(?:_eq|_ne)?!\([^;]*?\)(?:;|$)`)
+	trivialAssertPattern = regexp.MustCompile(`^\s*(?:debug_)?assert!\(\s*true\s*[,)]`)
+)
+
+// Violation is one denylisted pattern found in a generated test.
+type Violation struct {
+	// Rule names the denylist rule that matched, e.g. "network access".
+	Rule string
+	// Match is the offending snippet of code.
+	Match string
+}
+
+// String renders v as "rule (\"match\")", for embedding in a rejection
+// reason.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s (%q)", v.Rule, v.Match)
+}
+
+// Check scans code for std::net/reqwest use, absolute path string literals
+// outside a tempdir, and thread::sleep calls longer than MaxSleep, plus any
+// additional regular expressions in denylist, returning one Violation per
+// match.
+func Check(code string, denylist []string) []Violation {
+	var violations []Violation
+
+	for _, m := range networkPattern.FindAllString(code, -1) {
+		violations = append(violations, Violation{Rule: "network access", Match: m})
+	}
+
+	for _, m := range absolutePathPattern.FindAllStringSubmatch(code, -1) {
+		if !strings.Contains(m[1], "tmp") {
+			violations = append(violations, Violation{Rule: "absolute path outside a tempdir", Match: m[0]})
+		}
+	}
+
+	for _, m := range sleepSecsPattern.FindAllStringSubmatch(code, -1) {
+		if secs, err := strconv.Atoi(m[1]); err == nil && time.Duration(secs)*time.Second > MaxSleep {
+			violations = append(violations, Violation{Rule: "long sleep", Match: m[0]})
+		}
+	}
+	for _, m := range sleepMillisPattern.FindAllStringSubmatch(code, -1) {
+		if millis, err := strconv.Atoi(m[1]); err == nil && time.Duration(millis)*time.Millisecond > MaxSleep {
+			violations = append(violations, Violation{Rule: "long sleep", Match: m[0]})
+		}
+	}
+
+	for _, pat := range denylist {
+		re, err := regexp.Compile(pat)
+		if err != nil {
+			continue
+		}
+		for _, m := range re.FindAllString(code, -1) {
+			violations = append(violations, Violation{Rule: pat, Match: m})
+		}
+	}
+
+	return violations
+}
+
+// AssertionDensity returns the fraction of code's statements that are
+// non-trivial assertions — assert!, assert_eq!, assert_ne!, and their
+// debug_ variants, not counting assert!(true) which asserts nothing — so a
+// caller can reject a candidate that compiles and runs but never actually
+// checks anything, such as one that only calls a function and discards its
+// result. Returns 0 for code with no statements at all.
+func AssertionDensity(code string) float64 {
+	statements := statementPattern.FindAllString(code, -1)
+	if len(statements) == 0 {
+		return 0
+	}
+
+	assertions := 0
+	for _, m := range assertPattern.FindAllString(code, -1) {
+		if !trivialAssertPattern.MatchString(m) {
+			assertions++
+		}
+	}
+
+	return float64(assertions) / float64(len(statements))
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import "testing"
+
+func TestCheckFlagsNetworkAccess(t *testing.T) {
+	code := `let listener = std::net::TcpListener::bind("127.0.0.1:0").unwrap();`
+	violations := Check(code, nil)
+	if len(violations) != 1 || violations[0].Rule != "network access" {
+		t.Errorf("Check() = %+v, want one network access violation", violations)
+	}
+}
+
+func TestCheckFlagsAbsolutePathOutsideTempdir(t *testing.T) {
+	code := `std::fs::write("/etc/passwd", "pwned").unwrap();`
+	violations := Check(code, nil)
+	if len(violations) != 1 || violations[0].Rule != "absolute path outside a tempdir" {
+		t.Errorf("Check() = %+v, want one absolute path violation", violations)
+	}
+}
+
+func TestCheckAllowsTempdirPath(t *testing.T) {
+	code := `std::fs::write("/tmp/coverage-tool-test", "ok").unwrap();`
+	if violations := Check(code, nil); len(violations) != 0 {
+		t.Errorf("Check() = %+v, want no violations for a /tmp path", violations)
+	}
+}
+
+func TestCheckFlagsLongSleep(t *testing.T) {
+	code := `std::thread::sleep(std::time::Duration::from_secs(30));`
+	violations := Check(code, nil)
+	if len(violations) != 1 || violations[0].Rule != "long sleep" {
+		t.Errorf("Check() = %+v, want one long sleep violation", violations)
+	}
+}
+
+func TestCheckAllowsShortSleep(t *testing.T) {
+	code := `std::thread::sleep(std::time::Duration::from_millis(10));`
+	if violations := Check(code, nil); len(violations) != 0 {
+		t.Errorf("Check() = %+v, want no violations for a short sleep", violations)
+	}
+}
+
+func TestCheckAppliesExtraDenylistPatterns(t *testing.T) {
+	code := `let _ = unsafe { std::mem::transmute::<u32, f32>(1) };`
+	violations := Check(code, []string{`std::mem::transmute`})
+	if len(violations) != 1 || violations[0].Rule != `std::mem::transmute` {
+		t.Errorf("Check() = %+v, want one violation of the extra denylist pattern", violations)
+	}
+}
+
+func TestCheckReturnsNilForCleanCode(t *testing.T) {
+	code := `#[test]\nfn it_parses() {\n    assert_eq!(parse("1"), Some(1));\n}`
+	if violations := Check(code, nil); violations != nil {
+		t.Errorf("Check() = %+v, want nil", violations)
+	}
+}
+
+func TestAssertionDensityForRealAssertion(t *testing.T) {
+	code := `#[test]
+fn it_parses() {
+    let result = parse("1");
+    assert_eq!(result, Some(1));
+}`
+	if density := AssertionDensity(code); density != 0.5 {
+		t.Errorf("AssertionDensity() = %v, want 0.5", density)
+	}
+}
+
+func TestAssertionDensityZeroForTrivialAssertTrue(t *testing.T) {
+	code := `#[test]
+fn it_runs() {
+    parse("1");
+    assert!(true);
+}`
+	if density := AssertionDensity(code); density != 0 {
+		t.Errorf("AssertionDensity() = %v, want 0 for a test that only asserts true", density)
+	}
+}
+
+func TestAssertionDensityZeroForCallOnlyTest(t *testing.T) {
+	code := `#[test]
+fn it_runs() {
+    parse("1");
+}`
+	if density := AssertionDensity(code); density != 0 {
+		t.Errorf("AssertionDensity() = %v, want 0 for a test that only calls a function", density)
+	}
+}
+
+func TestAssertionDensityZeroForNoStatements(t *testing.T) {
+	if density := AssertionDensity(""); density != 0 {
+		t.Errorf("AssertionDensity() = %v, want 0 for empty code", density)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OpenAIEmbedder computes embedding vectors via an OpenAI-compatible
+// "/embeddings" endpoint, for provider-backed relevance ranking
+// (pkg/embed.Rank) as an alternative to the dependency-free
+// pkg/embed.Local.
+type OpenAIEmbedder struct {
+	// BaseURL is the endpoint's root, e.g. "https://api.openai.com/v1".
+	// "/embeddings" is appended to it.
+	BaseURL string
+	// APIKeyEnv is the name of the environment variable holding the
+	// bearer token to send, if the endpoint requires one.
+	APIKeyEnv string
+	// Model is the embedding model id to request.
+	Model string
+	// Timeout is the HTTP client timeout; zero falls back to
+	// defaultOpenAICompatibleTimeout.
+	Timeout time.Duration
+	// ProxyURL, if set, routes requests through this proxy instead of the
+	// standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+	ProxyURL string
+}
+
+type embeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns one embedding vector per text in texts, in the same
+// order.
+func (o OpenAIEmbedder) Embed(texts []string) ([][]float64, error) {
+	reqBody, err := json.Marshal(embeddingsRequest{Model: o.Model, Input: texts})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal request body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(o.BaseURL, "/")+"/embeddings", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.APIKeyEnv != "" {
+		if key := os.Getenv(o.APIKeyEnv); key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+	}
+
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = defaultOpenAICompatibleTimeout
+	}
+	client, err := newHTTPClient(timeout, o.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "call embeddings endpoint")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("embeddings endpoint returned status %d", resp.StatusCode)
+	}
+
+	var decoded embeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, errors.Wrap(err, "decode response")
+	}
+	if len(decoded.Data) != len(texts) {
+		return nil, errors.Errorf("embeddings endpoint returned %d vectors for %d inputs", len(decoded.Data), len(texts))
+	}
+
+	vectors := make([][]float64, len(decoded.Data))
+	for i, d := range decoded.Data {
+		vectors[i] = d.Embedding
+	}
+	return vectors, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAIEmbedderEmbed(t *testing.T) {
+	var gotReq embeddingsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_ = json.NewEncoder(w).Encode(embeddingsResponse{
+			Data: []struct {
+				Embedding []float64 `json:"embedding"`
+			}{
+				{Embedding: []float64{1, 0}},
+				{Embedding: []float64{0, 1}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	embedder := OpenAIEmbedder{BaseURL: server.URL, Model: "text-embedding-3-small"}
+	vectors, err := embedder.Embed([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Errorf("Embed() = %v, want the two mocked vectors", vectors)
+	}
+	if gotReq.Model != "text-embedding-3-small" || len(gotReq.Input) != 2 {
+		t.Errorf("request = %+v, want model and 2 inputs", gotReq)
+	}
+}
+
+func TestOpenAIEmbedderMismatchedVectorCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(embeddingsResponse{})
+	}))
+	defer server.Close()
+
+	embedder := OpenAIEmbedder{BaseURL: server.URL}
+	if _, err := embedder.Embed([]string{"a", "b"}); err == nil {
+		t.Error("Embed() should error when the endpoint returns fewer vectors than inputs")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const githubModelsAPI = "https://models.inference.ai.azure.com/chat/completions"
+
+// defaultGitHubModelsTimeout is used when GitHubModels.Timeout is zero.
+const defaultGitHubModelsTimeout = 120 * time.Second
+
+// GitHubModels talks to the GitHub Models chat completions endpoint. It
+// requires a GITHUB_TOKEN with models: read access.
+type GitHubModels struct {
+	// Timeout is the HTTP client timeout; zero falls back to
+	// defaultGitHubModelsTimeout.
+	Timeout time.Duration
+	// ProxyURL, if set, routes requests through this proxy instead of the
+	// standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+	ProxyURL string
+}
+
+func (GitHubModels) Name() string { return "github-models" }
+
+// httpClient builds this call's HTTP client, defaulting Timeout to
+// defaultGitHubModelsTimeout when unset.
+func (g GitHubModels) httpClient() (*http.Client, error) {
+	timeout := g.Timeout
+	if timeout == 0 {
+		timeout = defaultGitHubModelsTimeout
+	}
+	return newHTTPClient(timeout, g.ProxyURL)
+}
+
+type chatCompletionRequest struct {
+	Model       string     `json:"model"`
+	Messages    []chatMsg  `json:"messages"`
+	Temperature float64    `json:"temperature"`
+	TopP        float64    `json:"top_p,omitempty"`
+	MaxTokens   int        `json:"max_tokens,omitempty"`
+	Stream      bool       `json:"stream,omitempty"`
+	Tools       []toolSpec `json:"tools,omitempty"`
+}
+
+// chatMessages assembles the message list for a chat-completion request,
+// prepending a system message when opts.SystemPrompt is set.
+func chatMessages(prompt string, opts Options) []chatMsg {
+	messages := make([]chatMsg, 0, 2)
+	if opts.SystemPrompt != "" {
+		messages = append(messages, chatMsg{Role: "system", Content: opts.SystemPrompt})
+	}
+	return append(messages, chatMsg{Role: "user", Content: prompt})
+}
+
+type chatMsg struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []toolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMsg `json:"message"`
+	} `json:"choices"`
+	Usage chatCompletionUsage `json:"usage"`
+}
+
+// chatCompletionUsage is the OpenAI-shaped token accounting block returned
+// alongside a (non-streamed) chat completion.
+type chatCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+func (u chatCompletionUsage) toUsage() Usage {
+	return Usage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens}
+}
+
+// Complete sends prompt to the GitHub Models chat completions endpoint and
+// returns the generated text and its billed token usage, serving any
+// opts.Tools calls the model makes along the way.
+func (g GitHubModels) Complete(model, prompt string, opts Options) (string, Usage, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return "", Usage{}, errors.New("GITHUB_TOKEN is not set")
+	}
+
+	return runToolLoop(func(messages []chatMsg) (chatMsg, chatCompletionUsage, error) {
+		reqBody, err := json.Marshal(chatCompletionRequest{
+			Model:       model,
+			Messages:    messages,
+			Temperature: opts.Temperature,
+			TopP:        opts.TopP,
+			MaxTokens:   opts.MaxTokens,
+			Tools:       toolSpecs(opts.Tools),
+		})
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "marshal request body")
+		}
+
+		req, err := http.NewRequest(http.MethodPost, githubModelsAPI, bytes.NewReader(reqBody))
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "build request")
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		client, err := g.httpClient()
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "call GitHub Models API")
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			return chatMsg{}, chatCompletionUsage{}, &RetryableError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+		}
+		if resp.StatusCode != http.StatusOK {
+			return chatMsg{}, chatCompletionUsage{}, errors.Errorf("GitHub Models API returned status %d", resp.StatusCode)
+		}
+
+		var completion chatCompletionResponse
+		if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "decode response")
+		}
+		if len(completion.Choices) == 0 {
+			return chatMsg{}, chatCompletionUsage{}, errors.New("GitHub Models API returned no choices")
+		}
+
+		return completion.Choices[0].Message, completion.Usage, nil
+	}, prompt, opts)
+}
+
+// CompleteStream is Complete, but streams the response via server-sent
+// events, invoking onChunk with each piece of generated text as it
+// arrives. The GitHub Models streaming API does not report token usage
+// mid-stream, so the returned Usage is estimated from prompt and response
+// length rather than billed exactly.
+func (g GitHubModels) CompleteStream(model, prompt string, opts Options, onChunk StreamFunc) (string, Usage, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return "", Usage{}, errors.New("GITHUB_TOKEN is not set")
+	}
+
+	reqBody, err := json.Marshal(chatCompletionRequest{
+		Model:       model,
+		Messages:    chatMessages(prompt, opts),
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		MaxTokens:   opts.MaxTokens,
+		Stream:      true,
+	})
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "marshal request body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, githubModelsAPI, bytes.NewReader(reqBody))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client, err := g.httpClient()
+	if err != nil {
+		return "", Usage{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "call GitHub Models API")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", Usage{}, errors.Errorf("GitHub Models API returned status %d", resp.StatusCode)
+	}
+
+	generated, err := readOpenAISSEStream(resp.Body, onChunk)
+	if err != nil {
+		return "", Usage{}, err
+	}
+
+	return generated, estimatedUsage(prompt, generated), nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// newHTTPClient returns an http.Client with the given timeout. Go's default
+// transport already honors the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+// environment variables; when proxyURL is set it takes precedence over
+// them, for corporate networks where a provider-specific proxy differs from
+// the process-wide one.
+func newHTTPClient(timeout time.Duration, proxyURL string) (*http.Client, error) {
+	if proxyURL == "" {
+		return &http.Client{Timeout: timeout}, nil
+	}
+
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse --proxy")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(parsed)
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClientAppliesTimeout(t *testing.T) {
+	client, err := newHTTPClient(5*time.Second, "")
+	if err != nil {
+		t.Fatalf("newHTTPClient returned error: %v", err)
+	}
+	if client.Timeout != 5*time.Second {
+		t.Errorf("client.Timeout = %v, want 5s", client.Timeout)
+	}
+}
+
+func TestNewHTTPClientRejectsInvalidProxyURL(t *testing.T) {
+	if _, err := newHTTPClient(time.Second, "://not-a-url"); err == nil {
+		t.Error("newHTTPClient should error on an invalid --proxy URL")
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultLlamaCppBaseURL is a local llama.cpp server's default listen
+// address.
+const defaultLlamaCppBaseURL = "http://localhost:8080"
+
+// LlamaCpp talks to a local llama.cpp server
+// (https://github.com/ggerganov/llama.cpp) via its native /completion
+// endpoint, so an air-gapped user with a GGUF model file can run the full
+// pipeline with no GITHUB_TOKEN or hosted API required.
+type LlamaCpp struct {
+	// Timeout is the HTTP client timeout; zero falls back to
+	// defaultLlamaCppTimeout. Local model inference can be much slower
+	// than a hosted API, hence the higher default than the other
+	// providers.
+	Timeout time.Duration
+	// ProxyURL, if set, routes requests through this proxy instead of the
+	// standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+	ProxyURL string
+}
+
+// defaultLlamaCppTimeout is used when LlamaCpp.Timeout is zero.
+const defaultLlamaCppTimeout = 300 * time.Second
+
+func (LlamaCpp) Name() string { return "llama.cpp" }
+
+// httpClient builds this call's HTTP client, defaulting Timeout to
+// defaultLlamaCppTimeout when unset.
+func (l LlamaCpp) httpClient() (*http.Client, error) {
+	timeout := l.Timeout
+	if timeout == 0 {
+		timeout = defaultLlamaCppTimeout
+	}
+	return newHTTPClient(timeout, l.ProxyURL)
+}
+
+// llamaCppRequest is llama.cpp server's native /completion request body,
+// which takes a single already-assembled prompt rather than a chat
+// message list.
+type llamaCppRequest struct {
+	Prompt      string  `json:"prompt"`
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p,omitempty"`
+	NPredict    int     `json:"n_predict,omitempty"`
+	Stream      bool    `json:"stream"`
+}
+
+// llamaCppRequestBody builds a llamaCppRequest from prompt and opts,
+// folding opts.SystemPrompt into the prompt text since /completion has no
+// separate system-message field.
+func llamaCppRequestBody(prompt string, opts Options, stream bool) llamaCppRequest {
+	if opts.SystemPrompt != "" {
+		prompt = opts.SystemPrompt + "\n\n" + prompt
+	}
+	return llamaCppRequest{
+		Prompt:      prompt,
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		NPredict:    opts.MaxTokens,
+		Stream:      stream,
+	}
+}
+
+type llamaCppResponse struct {
+	Content string `json:"content"`
+	// TokensEvaluated and TokensPredicted are llama.cpp's names for
+	// prompt and completion token counts; they are only populated once
+	// Stop is true.
+	TokensEvaluated int  `json:"tokens_evaluated"`
+	TokensPredicted int  `json:"tokens_predicted"`
+	Stop            bool `json:"stop"`
+}
+
+func (r llamaCppResponse) toUsage() Usage {
+	return Usage{PromptTokens: r.TokensEvaluated, CompletionTokens: r.TokensPredicted}
+}
+
+// Complete sends prompt to a local llama.cpp server's /completion endpoint
+// and returns the generated text and its token usage. model is accepted to
+// satisfy Provider but ignored: a llama.cpp server only ever serves the one
+// GGUF model it was launched with.
+func (l LlamaCpp) Complete(_, prompt string, opts Options) (string, Usage, error) {
+	reqBody, err := json.Marshal(llamaCppRequestBody(prompt, opts, false))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "marshal request body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, defaultLlamaCppBaseURL+"/completion", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client, err := l.httpClient()
+	if err != nil {
+		return "", Usage{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "call llama.cpp server")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", Usage{}, errors.Errorf("llama.cpp server returned status %d", resp.StatusCode)
+	}
+
+	var generated llamaCppResponse
+	if err := json.NewDecoder(resp.Body).Decode(&generated); err != nil {
+		return "", Usage{}, errors.Wrap(err, "decode response")
+	}
+
+	return generated.Content, generated.toUsage(), nil
+}
+
+// CompleteStream is Complete, but streams the response as server-sent
+// events, invoking onChunk with each piece of generated text as it
+// arrives. The final event carries the token usage for the whole call.
+func (l LlamaCpp) CompleteStream(_, prompt string, opts Options, onChunk StreamFunc) (string, Usage, error) {
+	reqBody, err := json.Marshal(llamaCppRequestBody(prompt, opts, true))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "marshal request body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, defaultLlamaCppBaseURL+"/completion", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	client, err := l.httpClient()
+	if err != nil {
+		return "", Usage{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "call llama.cpp server")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", Usage{}, errors.Errorf("llama.cpp server returned status %d", resp.StatusCode)
+	}
+
+	var full strings.Builder
+	var usage Usage
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimPrefix(line, "data: ")
+		if line == "" {
+			continue
+		}
+
+		var chunk llamaCppResponse
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return "", Usage{}, errors.Wrap(err, "decode stream chunk")
+		}
+
+		full.WriteString(chunk.Content)
+		onChunk(chunk.Content)
+		if chunk.Stop {
+			usage = chunk.toUsage()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", Usage{}, errors.Wrap(err, "read stream")
+	}
+
+	return full.String(), usage, nil
+}
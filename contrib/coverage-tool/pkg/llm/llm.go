@@ -0,0 +1,85 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package llm talks to whichever chat-completion API a coverage-tool run is
+// configured to use, so cmd/generate.go can ask for generated text without
+// knowing which provider's request/response shape is behind it.
+package llm
+
+import (
+	"fmt"
+	"time"
+)
+
+// Provider completes a single prompt against a chat-completion API.
+type Provider interface {
+	// Name identifies the provider for --llm-provider flags and reports.
+	Name() string
+	// Complete returns the model's response to prompt and the token usage
+	// billed for the call, for cost estimation.
+	Complete(model, prompt string, opts Options) (string, Usage, error)
+}
+
+// StreamFunc receives an incremental chunk of generated text as it
+// arrives.
+type StreamFunc func(chunk string)
+
+// StreamingProvider is implemented by providers that can stream generated
+// text incrementally, so a long-running call doesn't sit silent until the
+// full response lands.
+type StreamingProvider interface {
+	Provider
+	// CompleteStream is Complete, but also invokes onChunk with each
+	// incremental piece of text as it arrives; it still returns the full
+	// assembled response and its usage.
+	CompleteStream(model, prompt string, opts Options, onChunk StreamFunc) (string, Usage, error)
+}
+
+// Options carries the generation parameters a caller may want to tune per
+// call, so determinism and length can be dialed in (e.g. for CI) without
+// each provider hardcoding its own defaults.
+type Options struct {
+	// SystemPrompt, if non-empty, is sent ahead of the user prompt via the
+	// provider's system-message mechanism.
+	SystemPrompt string
+	// Temperature controls sampling randomness; lower is more
+	// deterministic.
+	Temperature float64
+	// TopP is the nucleus-sampling probability mass to consider.
+	TopP float64
+	// MaxTokens caps the length of the generated completion; 0 leaves it
+	// to the provider's own default.
+	MaxTokens int
+	// Tools, if non-empty, are offered to the model as function calls it
+	// may make instead of (or before) its final answer. Only supported
+	// by Complete, not CompleteStream.
+	Tools []Tool
+	// ToolHandler serves the calls the model makes against Tools. It is
+	// required if Tools is non-empty.
+	ToolHandler ToolHandler
+}
+
+// DefaultOptions is the generation parameters used when a caller doesn't
+// override them, matching coverage-tool's previously hardcoded behavior.
+func DefaultOptions() Options {
+	return Options{Temperature: 0.7, TopP: 1}
+}
+
+// Get returns the provider registered under name, or an error if name is
+// unknown. timeout and proxyURL configure the provider's HTTP client; a
+// zero timeout falls back to defaultHTTPTimeout, and an empty proxyURL
+// leaves proxy selection to the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+// environment variables.
+func Get(name string, timeout time.Duration, proxyURL string) (Provider, error) {
+	switch name {
+	case "", "github-models":
+		return GitHubModels{Timeout: timeout, ProxyURL: proxyURL}, nil
+	case "ollama":
+		return Ollama{Timeout: timeout, ProxyURL: proxyURL}, nil
+	case "llama.cpp":
+		return LlamaCpp{Timeout: timeout, ProxyURL: proxyURL}, nil
+	default:
+		return nil, fmt.Errorf("unknown llm provider %q", name)
+	}
+}
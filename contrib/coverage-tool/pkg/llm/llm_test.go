@@ -0,0 +1,173 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	if p, err := Get("", 0, ""); err != nil || p.Name() != "github-models" {
+		t.Errorf("Get(\"\") = %v, %v; want github-models provider", p, err)
+	}
+	if p, err := Get("github-models", 0, ""); err != nil || p.Name() != "github-models" {
+		t.Errorf("Get(\"github-models\") = %v, %v; want github-models provider", p, err)
+	}
+	if p, err := Get("ollama", 0, ""); err != nil || p.Name() != "ollama" {
+		t.Errorf("Get(\"ollama\") = %v, %v; want ollama provider", p, err)
+	}
+	if p, err := Get("llama.cpp", 0, ""); err != nil || p.Name() != "llama.cpp" {
+		t.Errorf("Get(\"llama.cpp\") = %v, %v; want llama.cpp provider", p, err)
+	}
+	if _, err := Get("bogus", 0, ""); err == nil {
+		t.Error("Get(\"bogus\") should return an error")
+	}
+}
+
+func TestOpenAICompatibleComplete(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(chatCompletionResponse{
+			Choices: []struct {
+				Message chatMsg `json:"message"`
+			}{{Message: chatMsg{Role: "assistant", Content: "generated test"}}},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_API_KEY", "secret")
+	provider := OpenAICompatible{BaseURL: server.URL, APIKeyEnv: "TEST_API_KEY"}
+
+	got, _, err := provider.Complete("qwen-coder", "write a test", Options{})
+	if err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+	if got != "generated test" {
+		t.Errorf("Complete() = %q, want %q", got, "generated test")
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Authorization header = %q, want Bearer secret", gotAuth)
+	}
+}
+
+func TestOpenAICompatibleCompleteStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"fn \"}}]}\n\n"))
+		_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"test() {}\"}}]}\n\n"))
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	provider := OpenAICompatible{BaseURL: server.URL}
+
+	var streamed string
+	got, _, err := provider.CompleteStream("qwen-coder", "write a test", Options{}, func(c string) { streamed += c })
+	if err != nil {
+		t.Fatalf("CompleteStream returned error: %v", err)
+	}
+	if got != "fn test() {}" {
+		t.Errorf("CompleteStream() = %q, want %q", got, "fn test() {}")
+	}
+	if streamed != got {
+		t.Errorf("streamed chunks = %q, want them to assemble to %q", streamed, got)
+	}
+}
+
+func TestOpenAICompatibleCompleteSendsOptions(t *testing.T) {
+	var gotReq chatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_ = json.NewEncoder(w).Encode(chatCompletionResponse{
+			Choices: []struct {
+				Message chatMsg `json:"message"`
+			}{{Message: chatMsg{Role: "assistant", Content: "generated"}}},
+		})
+	}))
+	defer server.Close()
+
+	provider := OpenAICompatible{BaseURL: server.URL}
+	_, _, err := provider.Complete("qwen-coder", "write a test", Options{SystemPrompt: "be terse", Temperature: 0, TopP: 0.5, MaxTokens: 256})
+	if err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+
+	if len(gotReq.Messages) != 2 || gotReq.Messages[0].Role != "system" || gotReq.Messages[0].Content != "be terse" {
+		t.Errorf("Messages = %v, want a leading system message", gotReq.Messages)
+	}
+	if gotReq.TopP != 0.5 || gotReq.MaxTokens != 256 {
+		t.Errorf("TopP/MaxTokens = %v/%v, want 0.5/256", gotReq.TopP, gotReq.MaxTokens)
+	}
+}
+
+func TestOpenAICompatibleCompleteServesToolCalls(t *testing.T) {
+	round := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatCompletionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		round++
+		if round == 1 {
+			_ = json.NewEncoder(w).Encode(chatCompletionResponse{
+				Choices: []struct {
+					Message chatMsg `json:"message"`
+				}{{Message: chatMsg{
+					Role: "assistant",
+					ToolCalls: []toolCall{{
+						ID:   "call-1",
+						Type: "function",
+						Function: struct {
+							Name      string `json:"name"`
+							Arguments string `json:"arguments"`
+						}{Name: "read_file", Arguments: `{"path":"a.rs"}`},
+					}},
+				}}},
+			})
+			return
+		}
+
+		if len(req.Messages) != 3 || req.Messages[2].Role != "tool" || req.Messages[2].Content != "file contents" {
+			t.Errorf("second round messages = %+v, want a trailing tool result", req.Messages)
+		}
+		_ = json.NewEncoder(w).Encode(chatCompletionResponse{
+			Choices: []struct {
+				Message chatMsg `json:"message"`
+			}{{Message: chatMsg{Role: "assistant", Content: "generated test"}}},
+		})
+	}))
+	defer server.Close()
+
+	provider := OpenAICompatible{BaseURL: server.URL}
+	handler := func(name string, arguments json.RawMessage) (string, error) {
+		if name != "read_file" {
+			t.Fatalf("handler called with unexpected tool %q", name)
+		}
+		return "file contents", nil
+	}
+
+	got, _, err := provider.Complete("qwen-coder", "write a test", Options{
+		Tools:       []Tool{{Name: "read_file"}},
+		ToolHandler: handler,
+	})
+	if err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+	if got != "generated test" {
+		t.Errorf("Complete() = %q, want %q", got, "generated test")
+	}
+	if round != 2 {
+		t.Errorf("server received %d requests, want 2", round)
+	}
+}
+
+func TestGitHubModelsImplementsStreamingProvider(t *testing.T) {
+	var _ StreamingProvider = GitHubModels{}
+	var _ StreamingProvider = OpenAICompatible{}
+	var _ StreamingProvider = Ollama{}
+	var _ StreamingProvider = LlamaCpp{}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultOllamaBaseURL is a local Ollama server's default listen address.
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// Ollama talks to a local Ollama server (https://ollama.com), so
+// generation works fully offline with models such as codellama or
+// qwen-coder, with no GITHUB_TOKEN required.
+type Ollama struct {
+	// Timeout is the HTTP client timeout; zero falls back to
+	// defaultOllamaTimeout. Local model inference can be much slower than
+	// a hosted API, hence the higher default than the other providers.
+	Timeout time.Duration
+	// ProxyURL, if set, routes requests through this proxy instead of the
+	// standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+	ProxyURL string
+}
+
+// defaultOllamaTimeout is used when Ollama.Timeout is zero.
+const defaultOllamaTimeout = 300 * time.Second
+
+func (Ollama) Name() string { return "ollama" }
+
+// httpClient builds this call's HTTP client, defaulting Timeout to
+// defaultOllamaTimeout when unset.
+func (o Ollama) httpClient() (*http.Client, error) {
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = defaultOllamaTimeout
+	}
+	return newHTTPClient(timeout, o.ProxyURL)
+}
+
+type ollamaGenerateRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	System string `json:"system,omitempty"`
+	Stream bool   `json:"stream"`
+
+	Options *ollamaOptions `json:"options,omitempty"`
+}
+
+// ollamaOptions is Ollama's per-request sampling parameters block.
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p,omitempty"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+// ollamaRequest builds an ollamaGenerateRequest from opts, so Complete and
+// CompleteStream don't repeat the field mapping.
+func ollamaRequest(model, prompt string, opts Options, stream bool) ollamaGenerateRequest {
+	return ollamaGenerateRequest{
+		Model:  model,
+		Prompt: prompt,
+		System: opts.SystemPrompt,
+		Stream: stream,
+		Options: &ollamaOptions{
+			Temperature: opts.Temperature,
+			TopP:        opts.TopP,
+			NumPredict:  opts.MaxTokens,
+		},
+	}
+}
+
+type ollamaGenerateResponse struct {
+	Response string `json:"response"`
+	// PromptEvalCount and EvalCount are Ollama's names for prompt and
+	// completion token counts; they are only populated once done is true.
+	PromptEvalCount int  `json:"prompt_eval_count"`
+	EvalCount       int  `json:"eval_count"`
+	Done            bool `json:"done"`
+}
+
+func (r ollamaGenerateResponse) toUsage() Usage {
+	return Usage{PromptTokens: r.PromptEvalCount, CompletionTokens: r.EvalCount}
+}
+
+// Complete sends prompt to a local Ollama server's /api/generate endpoint
+// and returns the generated text and its token usage.
+func (o Ollama) Complete(model, prompt string, opts Options) (string, Usage, error) {
+	reqBody, err := json.Marshal(ollamaRequest(model, prompt, opts, false))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "marshal request body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, defaultOllamaBaseURL+"/api/generate", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client, err := o.httpClient()
+	if err != nil {
+		return "", Usage{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "call Ollama server")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", Usage{}, errors.Errorf("Ollama server returned status %d", resp.StatusCode)
+	}
+
+	var generated ollamaGenerateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&generated); err != nil {
+		return "", Usage{}, errors.Wrap(err, "decode response")
+	}
+
+	return generated.Response, generated.toUsage(), nil
+}
+
+// CompleteStream is Complete, but streams the response as newline-delimited
+// JSON objects, invoking onChunk with each piece of generated text as it
+// arrives. The final line carries the token usage for the whole call.
+func (o Ollama) CompleteStream(model, prompt string, opts Options, onChunk StreamFunc) (string, Usage, error) {
+	reqBody, err := json.Marshal(ollamaRequest(model, prompt, opts, true))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "marshal request body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, defaultOllamaBaseURL+"/api/generate", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client, err := o.httpClient()
+	if err != nil {
+		return "", Usage{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "call Ollama server")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", Usage{}, errors.Errorf("Ollama server returned status %d", resp.StatusCode)
+	}
+
+	var full strings.Builder
+	var usage Usage
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var chunk ollamaGenerateResponse
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return "", Usage{}, errors.Wrap(err, "decode stream chunk")
+		}
+
+		full.WriteString(chunk.Response)
+		onChunk(chunk.Response)
+		if chunk.Done {
+			usage = chunk.toUsage()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", Usage{}, errors.Wrap(err, "read stream")
+	}
+
+	return full.String(), usage, nil
+}
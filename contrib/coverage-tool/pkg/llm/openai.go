@@ -0,0 +1,157 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OpenAICompatible talks to any chat-completion endpoint that speaks the
+// OpenAI API shape, such as a self-hosted vLLM or LiteLLM gateway.
+type OpenAICompatible struct {
+	// BaseURL is the endpoint's root, e.g. "http://localhost:8000/v1".
+	// "/chat/completions" is appended to it.
+	BaseURL string
+	// APIKeyEnv is the name of the environment variable holding the
+	// bearer token to send, if the endpoint requires one.
+	APIKeyEnv string
+	// Timeout is the HTTP client timeout; zero falls back to
+	// defaultOpenAICompatibleTimeout.
+	Timeout time.Duration
+	// ProxyURL, if set, routes requests through this proxy instead of the
+	// standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+	ProxyURL string
+}
+
+// defaultOpenAICompatibleTimeout is used when OpenAICompatible.Timeout is
+// zero.
+const defaultOpenAICompatibleTimeout = 120 * time.Second
+
+func (OpenAICompatible) Name() string { return "openai-compatible" }
+
+// httpClient builds this call's HTTP client, defaulting Timeout to
+// defaultOpenAICompatibleTimeout when unset.
+func (o OpenAICompatible) httpClient() (*http.Client, error) {
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = defaultOpenAICompatibleTimeout
+	}
+	return newHTTPClient(timeout, o.ProxyURL)
+}
+
+// Complete sends prompt to BaseURL + "/chat/completions" and returns the
+// generated text and its billed token usage, serving any opts.Tools calls
+// the model makes along the way.
+func (o OpenAICompatible) Complete(model, prompt string, opts Options) (string, Usage, error) {
+	return runToolLoop(func(messages []chatMsg) (chatMsg, chatCompletionUsage, error) {
+		reqBody, err := json.Marshal(chatCompletionRequest{
+			Model:       model,
+			Messages:    messages,
+			Temperature: opts.Temperature,
+			TopP:        opts.TopP,
+			MaxTokens:   opts.MaxTokens,
+			Tools:       toolSpecs(opts.Tools),
+		})
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "marshal request body")
+		}
+
+		req, err := http.NewRequest(http.MethodPost, strings.TrimRight(o.BaseURL, "/")+"/chat/completions", bytes.NewReader(reqBody))
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "build request")
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if o.APIKeyEnv != "" {
+			if key := os.Getenv(o.APIKeyEnv); key != "" {
+				req.Header.Set("Authorization", "Bearer "+key)
+			}
+		}
+
+		client, err := o.httpClient()
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "call OpenAI-compatible endpoint")
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			return chatMsg{}, chatCompletionUsage{}, &RetryableError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+		}
+		if resp.StatusCode != http.StatusOK {
+			return chatMsg{}, chatCompletionUsage{}, errors.Errorf("OpenAI-compatible endpoint returned status %d", resp.StatusCode)
+		}
+
+		var completion chatCompletionResponse
+		if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+			return chatMsg{}, chatCompletionUsage{}, errors.Wrap(err, "decode response")
+		}
+		if len(completion.Choices) == 0 {
+			return chatMsg{}, chatCompletionUsage{}, errors.New("OpenAI-compatible endpoint returned no choices")
+		}
+
+		return completion.Choices[0].Message, completion.Usage, nil
+	}, prompt, opts)
+}
+
+// CompleteStream is Complete, but streams the response via server-sent
+// events, invoking onChunk with each piece of generated text as it
+// arrives. Usage is estimated from prompt and response length, since most
+// OpenAI-compatible gateways do not report exact usage mid-stream.
+func (o OpenAICompatible) CompleteStream(model, prompt string, opts Options, onChunk StreamFunc) (string, Usage, error) {
+	reqBody, err := json.Marshal(chatCompletionRequest{
+		Model:       model,
+		Messages:    chatMessages(prompt, opts),
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		MaxTokens:   opts.MaxTokens,
+		Stream:      true,
+	})
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "marshal request body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(o.BaseURL, "/")+"/chat/completions", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if o.APIKeyEnv != "" {
+		if key := os.Getenv(o.APIKeyEnv); key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+	}
+
+	client, err := o.httpClient()
+	if err != nil {
+		return "", Usage{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, errors.Wrap(err, "call OpenAI-compatible endpoint")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", Usage{}, errors.Errorf("OpenAI-compatible endpoint returned status %d", resp.StatusCode)
+	}
+
+	generated, err := readOpenAISSEStream(resp.Body, onChunk)
+	if err != nil {
+		return "", Usage{}, err
+	}
+
+	return generated, estimatedUsage(prompt, generated), nil
+}
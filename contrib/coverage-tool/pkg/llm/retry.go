@@ -0,0 +1,121 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryableError is returned by a provider for a response it believes is
+// worth retrying (HTTP 429/503), carrying the server's requested backoff
+// from a Retry-After header, if any.
+type RetryableError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *RetryableError) Error() string {
+	return fmt.Sprintf("retryable error: status %d", e.StatusCode)
+}
+
+// parseRetryAfter parses an HTTP Retry-After header, which is either a
+// number of seconds or an HTTP date, returning 0 if header is empty or
+// unparseable.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// RetryOptions configures WithRetry's backoff policy.
+type RetryOptions struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on
+	// each subsequent attempt (full jitter added on top), unless a
+	// RetryableError's RetryAfter says otherwise.
+	BaseDelay time.Duration
+}
+
+// DefaultRetryOptions is the retry policy generate and explain use unless
+// overridden by --retry-max-attempts/--retry-base-delay.
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{MaxAttempts: 3, BaseDelay: time.Second}
+}
+
+// retrying wraps a Provider, retrying Complete on a RetryableError with
+// jittered exponential backoff, honoring the error's Retry-After when set.
+type retrying struct {
+	Provider
+	opts RetryOptions
+}
+
+// WithRetry wraps provider so Complete retries on a RetryableError
+// (HTTP 429/503) instead of failing permanently on the first one.
+// Streaming calls are passed straight through, unretried, since a partial
+// stream may already have been printed to the terminal; if provider
+// implements StreamingProvider, so does the result.
+func WithRetry(provider Provider, opts RetryOptions) Provider {
+	r := retrying{Provider: provider, opts: opts}
+	if sp, ok := provider.(StreamingProvider); ok {
+		return &retryingStreaming{retrying: r, stream: sp}
+	}
+	return &r
+}
+
+// retryingStreaming is retrying plus a passthrough CompleteStream, so
+// WithRetry's result keeps satisfying StreamingProvider when its
+// underlying provider does.
+type retryingStreaming struct {
+	retrying
+	stream StreamingProvider
+}
+
+func (r *retryingStreaming) CompleteStream(model, prompt string, opts Options, onChunk StreamFunc) (string, Usage, error) {
+	return r.stream.CompleteStream(model, prompt, opts, onChunk)
+}
+
+func (r *retrying) Complete(model, prompt string, opts Options) (string, Usage, error) {
+	var lastErr error
+	for attempt := 0; attempt < r.opts.MaxAttempts; attempt++ {
+		result, usage, err := r.Provider.Complete(model, prompt, opts)
+		if err == nil {
+			return result, usage, nil
+		}
+		lastErr = err
+
+		var retryable *RetryableError
+		if !errors.As(err, &retryable) || attempt == r.opts.MaxAttempts-1 {
+			return "", Usage{}, err
+		}
+		time.Sleep(backoffDelay(r.opts.BaseDelay, attempt, retryable.RetryAfter))
+	}
+	return "", Usage{}, lastErr
+}
+
+// backoffDelay returns retryAfter when the server specified one, otherwise
+// an exponentially growing delay with full jitter.
+func backoffDelay(base time.Duration, attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	d := base * time.Duration(1<<attempt)
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeStreamingProvider struct {
+	completeCalls int
+	fail          int
+	response      string
+}
+
+func (f *fakeStreamingProvider) Name() string { return "fake" }
+
+func (f *fakeStreamingProvider) Complete(model, prompt string, opts Options) (string, Usage, error) {
+	f.completeCalls++
+	if f.completeCalls <= f.fail {
+		return "", Usage{}, &RetryableError{StatusCode: http.StatusTooManyRequests}
+	}
+	return f.response, Usage{}, nil
+}
+
+func (f *fakeStreamingProvider) CompleteStream(model, prompt string, opts Options, onChunk StreamFunc) (string, Usage, error) {
+	onChunk(f.response)
+	return f.response, Usage{}, nil
+}
+
+func TestWithRetryRetriesRetryableErrors(t *testing.T) {
+	provider := &fakeStreamingProvider{fail: 2, response: "generated"}
+	retried := WithRetry(provider, RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	got, _, err := retried.Complete("model", "prompt", Options{})
+	if err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+	if got != "generated" {
+		t.Errorf("Complete() = %q, want %q", got, "generated")
+	}
+	if provider.completeCalls != 3 {
+		t.Errorf("Complete called %d times, want 3", provider.completeCalls)
+	}
+}
+
+func TestWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	provider := &fakeStreamingProvider{fail: 5, response: "generated"}
+	retried := WithRetry(provider, RetryOptions{MaxAttempts: 2, BaseDelay: time.Millisecond})
+
+	if _, _, err := retried.Complete("model", "prompt", Options{}); err == nil {
+		t.Error("Complete should return an error once retries are exhausted")
+	}
+	if provider.completeCalls != 2 {
+		t.Errorf("Complete called %d times, want 2 (MaxAttempts)", provider.completeCalls)
+	}
+}
+
+func TestWithRetryDoesNotRetryNonRetryableErrors(t *testing.T) {
+	provider := &fakeProvider{err: errNotRetryable}
+	retried := WithRetry(provider, RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	if _, _, err := retried.Complete("model", "prompt", Options{}); err == nil {
+		t.Error("Complete should surface a non-retryable error")
+	}
+	if provider.completeCalls != 1 {
+		t.Errorf("Complete called %d times, want 1 (no retry for a non-retryable error)", provider.completeCalls)
+	}
+}
+
+func TestWithRetryPreservesStreamingCapability(t *testing.T) {
+	provider := &fakeStreamingProvider{response: "generated"}
+	retried := WithRetry(provider, DefaultRetryOptions())
+
+	sp, ok := retried.(StreamingProvider)
+	if !ok {
+		t.Fatal("WithRetry should preserve StreamingProvider when the wrapped provider implements it")
+	}
+	if got, _, err := sp.CompleteStream("model", "prompt", Options{}, func(string) {}); err != nil || got != "generated" {
+		t.Errorf("CompleteStream() = %q, %v; want %q, nil", got, err, "generated")
+	}
+}
+
+type fakeProvider struct {
+	completeCalls int
+	err           error
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Complete(model, prompt string, opts Options) (string, Usage, error) {
+	f.completeCalls++
+	return "", Usage{}, f.err
+}
+
+var errNotRetryable = &nonRetryableError{}
+
+type nonRetryableError struct{}
+
+func (*nonRetryableError) Error() string { return "permanent failure" }
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	if got := parseRetryAfter("2"); got != 2*time.Second {
+		t.Errorf("parseRetryAfter(\"2\") = %v, want 2s", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter(\"\") = %v, want 0", got)
+	}
+}
+
+func TestGitHubModelsReturnsRetryableErrorOn429(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	provider := OpenAICompatible{BaseURL: server.URL}
+
+	_, _, err := provider.Complete("model", "prompt", Options{})
+	if err == nil {
+		t.Fatal("Complete should return an error on 429")
+	}
+	var retryable *RetryableError
+	if !errors.As(err, &retryable) {
+		t.Fatalf("Complete error should be a *RetryableError, got %v", err)
+	}
+	if retryable.RetryAfter != time.Second {
+		t.Errorf("RetryAfter = %v, want 1s", retryable.RetryAfter)
+	}
+}
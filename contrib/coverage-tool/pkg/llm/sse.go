@@ -0,0 +1,70 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/tokenest"
+)
+
+// estimatedUsage approximates the token usage of a call whose provider did
+// not report exact figures, from the character length of the prompt and
+// generated text.
+func estimatedUsage(prompt, generated string) Usage {
+	return Usage{PromptTokens: tokenest.Estimate(prompt), CompletionTokens: tokenest.Estimate(generated)}
+}
+
+// chatCompletionChunk is a single OpenAI-shaped SSE streaming event.
+type chatCompletionChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// readOpenAISSEStream reads an OpenAI-shaped "data: {...}" SSE stream from
+// body, invoking onChunk with each delta's content as it arrives, and
+// returns the full assembled text once the "data: [DONE]" sentinel or EOF
+// is reached.
+func readOpenAISSEStream(body io.Reader, onChunk StreamFunc) (string, error) {
+	var full strings.Builder
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		data := strings.TrimPrefix(line, "data: ")
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", errors.Wrap(err, "decode stream chunk")
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		content := chunk.Choices[0].Delta.Content
+		full.WriteString(content)
+		onChunk(content)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Wrap(err, "read stream")
+	}
+
+	return full.String(), nil
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadOpenAISSEStream(t *testing.T) {
+	stream := "data: {\"choices\":[{\"delta\":{\"content\":\"fn \"}}]}\n\n" +
+		"data: {\"choices\":[{\"delta\":{\"content\":\"test() {}\"}}]}\n\n" +
+		"data: [DONE]\n\n"
+
+	var chunks []string
+	full, err := readOpenAISSEStream(strings.NewReader(stream), func(c string) { chunks = append(chunks, c) })
+	if err != nil {
+		t.Fatalf("readOpenAISSEStream returned error: %v", err)
+	}
+
+	if full != "fn test() {}" {
+		t.Errorf("readOpenAISSEStream() = %q, want %q", full, "fn test() {}")
+	}
+	if len(chunks) != 2 {
+		t.Errorf("onChunk called %d times, want 2", len(chunks))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Tool describes a function the model may call mid-generation to request
+// additional context, following the OpenAI function-calling schema.
+type Tool struct {
+	// Name is the identifier the model uses to invoke the tool.
+	Name string
+	// Description tells the model when and why to call the tool.
+	Description string
+	// Parameters is the tool's arguments as a JSON Schema object.
+	Parameters json.RawMessage
+}
+
+// ToolHandler serves a tool call by name with its JSON-encoded arguments,
+// returning the text to feed back to the model as the tool's result. A
+// non-nil error aborts the whole completion; a handler that wants the
+// model to see and recover from a bad call (e.g. a disallowed path)
+// should return that as its result string instead.
+type ToolHandler func(name string, arguments json.RawMessage) (string, error)
+
+// toolSpec is the OpenAI wire shape for one entry of a request's "tools"
+// array.
+type toolSpec struct {
+	Type     string       `json:"type"`
+	Function toolFunction `json:"function"`
+}
+
+type toolFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+// toolSpecs converts tools to the wire shape, or nil if there are none, so
+// callers can pass the result straight into chatCompletionRequest.Tools
+// without an omitempty surprise on an empty-but-non-nil slice.
+func toolSpecs(tools []Tool) []toolSpec {
+	if len(tools) == 0 {
+		return nil
+	}
+	specs := make([]toolSpec, len(tools))
+	for i, t := range tools {
+		specs[i] = toolSpec{Type: "function", Function: toolFunction{Name: t.Name, Description: t.Description, Parameters: t.Parameters}}
+	}
+	return specs
+}
+
+// toolCall is the OpenAI wire shape for one entry of a response message's
+// "tool_calls" array.
+type toolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// maxToolRounds bounds how many times a model may call a tool before the
+// loop below gives up, so a misbehaving model can't loop forever.
+const maxToolRounds = 8
+
+// runToolLoop drives an OpenAI-shaped chat-completion exchange: it sends
+// messages via send, and for as long as the model responds with
+// tool_calls instead of a final answer, serves each one via
+// opts.ToolHandler and feeds the result back as a "tool" message, up to
+// maxToolRounds times. Usage is summed across every round.
+func runToolLoop(send func(messages []chatMsg) (chatMsg, chatCompletionUsage, error), prompt string, opts Options) (string, Usage, error) {
+	messages := chatMessages(prompt, opts)
+	var total Usage
+
+	for round := 0; ; round++ {
+		reply, usage, err := send(messages)
+		if err != nil {
+			return "", Usage{}, err
+		}
+		total.PromptTokens += usage.PromptTokens
+		total.CompletionTokens += usage.CompletionTokens
+
+		if len(reply.ToolCalls) == 0 {
+			return reply.Content, total, nil
+		}
+		if round >= maxToolRounds {
+			return "", total, errors.Errorf("model made more than %d tool calls without a final answer", maxToolRounds)
+		}
+		if opts.ToolHandler == nil {
+			return "", total, errors.New("model requested a tool call but no ToolHandler is configured")
+		}
+
+		messages = append(messages, reply)
+		for _, call := range reply.ToolCalls {
+			result, err := opts.ToolHandler(call.Function.Name, json.RawMessage(call.Function.Arguments))
+			if err != nil {
+				return "", total, errors.Wrapf(err, "serve tool call %s", call.Function.Name)
+			}
+			messages = append(messages, chatMsg{Role: "tool", ToolCallID: call.ID, Content: result})
+		}
+	}
+}
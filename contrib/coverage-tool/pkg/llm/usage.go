@@ -0,0 +1,12 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+// Usage records how many tokens a completion call consumed, so callers can
+// estimate its dollar cost against a price table.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mail delivers a rendered report over SMTP, for teams that
+// review coverage runs over email instead of GitHub notifications.
+package mail
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Config holds the SMTP server and envelope details needed to send a
+// report. Password is a secret and is expected to come from an
+// environment variable rather than a command-line flag.
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// addr returns the SMTP server's host:port, as smtp.SendMail expects it.
+func (c Config) addr() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+// Send delivers body to cfg.To over SMTP, with subject as the message
+// subject. body is sent as HTML if html is true, otherwise as plain text.
+// Authentication is skipped when cfg.Username is empty, for SMTP relays
+// that only accept unauthenticated mail from trusted networks.
+func Send(cfg Config, subject, body string, html bool) error {
+	if len(cfg.To) == 0 {
+		return errors.New("no recipients: pass --email-to")
+	}
+
+	msg := buildMessage(cfg, subject, body, html)
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	if err := smtp.SendMail(cfg.addr(), auth, cfg.From, cfg.To, []byte(msg)); err != nil {
+		return errors.Wrap(err, "send report email")
+	}
+
+	return nil
+}
+
+// buildMessage renders subject and body as an RFC 5322 message with the
+// headers smtp.SendMail requires the caller to supply itself. subject and
+// cfg's From/To are header values that can come from data outside the
+// operator's control (e.g. subject embeds a coverage target's file path),
+// so any CR/LF they contain is stripped first — otherwise it would inject
+// arbitrary extra headers (e.g. a forged Bcc) into the message.
+func buildMessage(cfg Config, subject, body string, html bool) string {
+	contentType := "text/plain; charset=UTF-8"
+	if html {
+		contentType = "text/html; charset=UTF-8"
+	}
+
+	to := make([]string, len(cfg.To))
+	for i, addr := range cfg.To {
+		to[i] = stripCRLF(addr)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", stripCRLF(cfg.From))
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", stripCRLF(subject))
+	fmt.Fprintf(&msg, "Content-Type: %s\r\n", contentType)
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+	return msg.String()
+}
+
+// stripCRLF removes CR and LF from s, so a value that ends up in a header
+// line can't terminate it early and inject additional headers.
+func stripCRLF(s string) string {
+	s = strings.ReplaceAll(s, "\r", "")
+	return strings.ReplaceAll(s, "\n", "")
+}
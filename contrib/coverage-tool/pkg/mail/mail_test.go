@@ -0,0 +1,64 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildMessageSetsHeaders(t *testing.T) {
+	cfg := Config{From: "bot@example.com", To: []string{"a@example.com", "b@example.com"}}
+
+	msg := buildMessage(cfg, "Coverage report", "<h1>hi</h1>", true)
+
+	for _, want := range []string{
+		"From: bot@example.com\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: Coverage report\r\n",
+		"Content-Type: text/html; charset=UTF-8\r\n",
+		"\r\n\r\n<h1>hi</h1>",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("buildMessage() = %q, want it to contain %q", msg, want)
+		}
+	}
+}
+
+func TestBuildMessagePlainText(t *testing.T) {
+	msg := buildMessage(Config{}, "s", "body", false)
+	if !strings.Contains(msg, "Content-Type: text/plain; charset=UTF-8\r\n") {
+		t.Errorf("buildMessage() = %q, want a text/plain content type", msg)
+	}
+}
+
+func TestBuildMessageStripsCRLFFromSubject(t *testing.T) {
+	cfg := Config{From: "bot@example.com", To: []string{"a@example.com"}}
+
+	msg := buildMessage(cfg, "Coverage report: crates/foo/src/bar.rs\r\nBcc: evil@example.com", "body", false)
+
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("buildMessage() = %q, subject CRLF injected an extra header", msg)
+	}
+	if !strings.Contains(msg, "Subject: Coverage report: crates/foo/src/bar.rsBcc: evil@example.com\r\n") {
+		t.Errorf("buildMessage() = %q, want CR/LF stripped from the subject line", msg)
+	}
+}
+
+func TestBuildMessageStripsCRLFFromAddresses(t *testing.T) {
+	cfg := Config{From: "bot@example.com\r\nBcc: evil@example.com", To: []string{"a@example.com\nBcc: evil@example.com"}}
+
+	msg := buildMessage(cfg, "s", "body", false)
+
+	if strings.Contains(msg, "\r\nBcc:") || strings.Contains(msg, "\nBcc:") {
+		t.Errorf("buildMessage() = %q, From/To CRLF injected an extra header", msg)
+	}
+}
+
+func TestSendRequiresRecipients(t *testing.T) {
+	if err := Send(Config{}, "s", "b", false); err == nil {
+		t.Error("Send() with no recipients = nil error, want an error")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics renders a coverage-tool run's outcome as an
+// OpenMetrics text-format file, so generic CI metric collectors (e.g.
+// the Datadog agent or Grafana Alloy's textfile-style scrape configs)
+// can pick up results without coverage-tool-specific parsing.
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Snapshot is one report run's outcome, rendered by Write as one
+// OpenMetrics sample per field, labeled by File.
+type Snapshot struct {
+	File             string
+	BeforePercent    float64
+	AfterPercent     float64
+	PromptTokens     int
+	CompletionTokens int
+	CostUSD          float64
+	Accepted         bool
+}
+
+// sample is one OpenMetrics value within a metric family, identified by
+// its `{...}` label string.
+type sample struct {
+	labels string
+	value  float64
+}
+
+// family is one OpenMetrics metric family: a name, HELP text, and its
+// sample values, rendered in the order given so output is deterministic.
+type family struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+// Write renders snapshot as an OpenMetrics text-format exposition and
+// writes it to path, so a CI job can point a file-based scrape config at
+// coverage-tool's own output directory instead of running an exporter.
+func Write(path string, snapshot Snapshot) error {
+	file := escapeLabelValue(snapshot.File)
+
+	files := fmt.Sprintf(`file="%s"`, file)
+	families := []family{
+		{
+			name: "coverage_tool_line_coverage_percent",
+			help: "Line coverage percentage for the target file.",
+			samples: []sample{
+				{labels: files + `,stage="before"`, value: snapshot.BeforePercent},
+				{labels: files + `,stage="after"`, value: snapshot.AfterPercent},
+			},
+		},
+		{
+			name:    "coverage_tool_prompt_tokens",
+			help:    "Prompt tokens spent generating the target file's accepted candidate.",
+			samples: []sample{{labels: files, value: float64(snapshot.PromptTokens)}},
+		},
+		{
+			name:    "coverage_tool_completion_tokens",
+			help:    "Completion tokens spent generating the target file's accepted candidate.",
+			samples: []sample{{labels: files, value: float64(snapshot.CompletionTokens)}},
+		},
+		{
+			name:    "coverage_tool_cost_usd",
+			help:    "Estimated dollar cost of generating the target file's accepted candidate.",
+			samples: []sample{{labels: files, value: snapshot.CostUSD}},
+		},
+		{
+			name:    "coverage_tool_accepted",
+			help:    "Whether the target file's generated candidate was accepted (1) or not (0).",
+			samples: []sample{{labels: files, value: boolToFloat(snapshot.Accepted)}},
+		},
+	}
+
+	var b strings.Builder
+	for _, f := range families {
+		writeFamily(&b, f)
+	}
+	b.WriteString("# EOF\n")
+
+	return errors.Wrap(os.WriteFile(path, []byte(b.String()), 0o644), "write metrics file")
+}
+
+// writeFamily appends f's TYPE/HELP header and samples to b, in the
+// gauge exposition format OpenMetrics text scrapers expect.
+func writeFamily(b *strings.Builder, f family) {
+	fmt.Fprintf(b, "# TYPE %s gauge\n", f.name)
+	fmt.Fprintf(b, "# HELP %s %s\n", f.name, f.help)
+	for _, s := range f.samples {
+		fmt.Fprintf(b, "%s{%s} %v\n", f.name, s.labels, s.value)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// escapeLabelValue escapes a label value per the OpenMetrics text format:
+// backslash and double-quote are backslash-escaped, and newlines become a
+// literal `\n`.
+func escapeLabelValue(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
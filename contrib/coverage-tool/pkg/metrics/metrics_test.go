@@ -0,0 +1,68 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteEmitsOpenMetricsFamiliesAndEOF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+
+	err := Write(path, Snapshot{
+		File:             "storage/src/device.rs",
+		BeforePercent:    40,
+		AfterPercent:     60,
+		PromptTokens:     500,
+		CompletionTokens: 100,
+		CostUSD:          0.05,
+		Accepted:         true,
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		"# TYPE coverage_tool_line_coverage_percent gauge",
+		`coverage_tool_line_coverage_percent{file="storage/src/device.rs",stage="before"} 40`,
+		`coverage_tool_line_coverage_percent{file="storage/src/device.rs",stage="after"} 60`,
+		`coverage_tool_prompt_tokens{file="storage/src/device.rs"} 500`,
+		`coverage_tool_completion_tokens{file="storage/src/device.rs"} 100`,
+		`coverage_tool_cost_usd{file="storage/src/device.rs"} 0.05`,
+		`coverage_tool_accepted{file="storage/src/device.rs"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Write() output = %q, want it to contain %q", out, want)
+		}
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Errorf("Write() output = %q, want it to end with the OpenMetrics EOF marker", out)
+	}
+}
+
+func TestWriteEscapesLabelValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+
+	if err := Write(path, Snapshot{File: `weird"file\name.rs`}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if want := `file="weird\"file\\name.rs"`; !strings.Contains(string(data), want) {
+		t.Errorf("Write() output = %q, want it to contain the escaped label %q", string(data), want)
+	}
+}
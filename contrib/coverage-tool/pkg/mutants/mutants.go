@@ -0,0 +1,63 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mutants parses `cargo mutants` outcome files, so a candidate's
+// tests can be rejected for not actually constraining behavior even though
+// they pass and improve line coverage.
+package mutants
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Score is a mutation-testing kill rate: how many of the mutants introduced
+// into a file were caught by the test suite.
+type Score struct {
+	Caught int
+	Missed int
+}
+
+// Rate returns the fraction of mutants Caught, in [0, 1]. A run with no
+// mutants at all (nothing left to mutate, or none survived triage) reports
+// a perfect 1.
+func (s Score) Rate() float64 {
+	total := s.Caught + s.Missed
+	if total == 0 {
+		return 1
+	}
+	return float64(s.Caught) / float64(total)
+}
+
+// outcomesFile is the subset of `cargo mutants`' mutants.out/outcomes.json
+// we rely on.
+type outcomesFile struct {
+	Outcomes []struct {
+		Summary string `json:"summary"`
+	} `json:"outcomes"`
+}
+
+// Parse reads a `cargo mutants` mutants.out/outcomes.json file and returns
+// the resulting Score. Outcomes other than a caught or missed mutant (the
+// unmutated baseline build, and mutants ruled Unviable or Timeout) are
+// ignored, matching cargo-mutants' own "mutants tested" accounting.
+func Parse(r io.Reader) (Score, error) {
+	var f outcomesFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return Score{}, errors.Wrap(err, "decode cargo-mutants outcomes.json")
+	}
+
+	var score Score
+	for _, o := range f.Outcomes {
+		switch o.Summary {
+		case "CaughtMutant":
+			score.Caught++
+		case "MissedMutant":
+			score.Missed++
+		}
+	}
+	return score, nil
+}
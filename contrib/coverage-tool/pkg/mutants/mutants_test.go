@@ -0,0 +1,39 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mutants
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleOutcomes = `{
+	"outcomes": [
+		{"scenario": "Baseline", "summary": "Success"},
+		{"scenario": {"Mutant": {}}, "summary": "CaughtMutant"},
+		{"scenario": {"Mutant": {}}, "summary": "CaughtMutant"},
+		{"scenario": {"Mutant": {}}, "summary": "MissedMutant"},
+		{"scenario": {"Mutant": {}}, "summary": "Unviable"}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	score, err := Parse(strings.NewReader(sampleOutcomes))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if score.Caught != 2 || score.Missed != 1 {
+		t.Errorf("Parse() = %+v, want {Caught: 2, Missed: 1}", score)
+	}
+	if got, want := score.Rate(), 2.0/3.0; got != want {
+		t.Errorf("Rate() = %v, want %v", got, want)
+	}
+}
+
+func TestRateForNoMutants(t *testing.T) {
+	if got, want := (Score{}).Rate(), 1.0; got != want {
+		t.Errorf("Rate() = %v, want %v for no mutants tested", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notify posts compact run-completion messages to external
+// channels, starting with Slack incoming webhooks, so a team can watch
+// coverage-improvement runs without polling CI.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Severity is how important a notification is, so a channel can be
+// configured to only surface notifications at or above a minimum level.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// severityRank orders Severity from least to most important, for
+// comparison against a configured minimum.
+var severityRank = map[Severity]int{
+	SeverityInfo:    0,
+	SeverityWarning: 1,
+	SeverityError:   2,
+}
+
+// ParseSeverity parses a --min-severity flag value.
+func ParseSeverity(s string) (Severity, error) {
+	switch Severity(s) {
+	case SeverityInfo, SeverityWarning, SeverityError:
+		return Severity(s), nil
+	default:
+		return "", errors.Errorf("unknown severity %q (want info, warning, or error)", s)
+	}
+}
+
+// ShouldNotify reports whether a notification at severity meets min.
+func ShouldNotify(severity, min Severity) bool {
+	return severityRank[severity] >= severityRank[min]
+}
+
+// Run summarizes a single coverage-tool run for notification.
+type Run struct {
+	File    string
+	Model   string
+	Before  float64
+	After   float64
+	PRURL   string
+	Success bool
+}
+
+// Severity classifies run: a failed run is an error, a successful run
+// that regressed coverage is a warning, and anything else is informational.
+func (r Run) Severity() Severity {
+	switch {
+	case !r.Success:
+		return SeverityError
+	case r.After < r.Before:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// Message renders a compact, single-line summary of run.
+func (r Run) Message() string {
+	status := "✅ succeeded"
+	if !r.Success {
+		status = "❌ failed"
+	}
+
+	msg := fmt.Sprintf("%s: %s (%.1f%% → %.1f%%) on %s", r.File, status, r.Before, r.After, r.Model)
+	if r.PRURL != "" {
+		msg += " " + r.PRURL
+	}
+	return msg
+}
+
+// slackPayload is a Slack incoming webhook's minimal request body.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// PostSlack posts text to a Slack incoming webhook URL.
+func PostSlack(webhookURL, text string) error {
+	payload, err := json.Marshal(slackPayload{Text: text})
+	if err != nil {
+		return errors.Wrap(err, "marshal slack payload")
+	}
+
+	resp, err := http.Post(webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "post slack webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
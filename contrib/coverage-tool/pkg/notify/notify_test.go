@@ -0,0 +1,52 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunSeverity(t *testing.T) {
+	cases := []struct {
+		name string
+		run  Run
+		want Severity
+	}{
+		{"failed", Run{Success: false, Before: 50, After: 60}, SeverityError},
+		{"regressed", Run{Success: true, Before: 60, After: 50}, SeverityWarning},
+		{"improved", Run{Success: true, Before: 50, After: 60}, SeverityInfo},
+	}
+	for _, c := range cases {
+		if got := c.run.Severity(); got != c.want {
+			t.Errorf("%s: Severity() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestRunMessageIncludesPRLink(t *testing.T) {
+	run := Run{File: "storage/src/device.rs", Model: "gpt-4o-mini", Before: 40, After: 75, Success: true, PRURL: "https://github.com/bergwolf/nydus/pull/1"}
+	msg := run.Message()
+	for _, want := range []string{"storage/src/device.rs", "40.0%", "75.0%", "gpt-4o-mini", run.PRURL} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Message() = %q, want it to contain %q", msg, want)
+		}
+	}
+}
+
+func TestShouldNotify(t *testing.T) {
+	if !ShouldNotify(SeverityError, SeverityWarning) {
+		t.Error("ShouldNotify(error, warning) should be true")
+	}
+	if ShouldNotify(SeverityInfo, SeverityWarning) {
+		t.Error("ShouldNotify(info, warning) should be false")
+	}
+}
+
+func TestParseSeverityRejectsUnknown(t *testing.T) {
+	if _, err := ParseSeverity("critical"); err == nil {
+		t.Error("ParseSeverity(\"critical\") should return an error")
+	}
+}
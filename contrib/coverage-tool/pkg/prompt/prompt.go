@@ -0,0 +1,59 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prompt renders a generate prompt from a team-supplied
+// text/template file, so prompting can be tuned via --prompt-template
+// instead of recompiling coverage-tool.
+package prompt
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+// Data is the set of variables available to a --prompt-template file.
+type Data struct {
+	// FileContent is the target file's source.
+	FileContent string
+	// ModuleContext is the rendered content of sibling files in the
+	// target file's module, after any --context-window trimming.
+	ModuleContext string
+	// Stats is the target file's coverage, or its zero value if no
+	// --coverage-file was given.
+	Stats coverage.FileStats
+	// UncoveredFunctionCount is Stats.FunctionsTotal minus
+	// Stats.FunctionsCovered. coverage-tool's llvm-cov parser only
+	// tracks per-file function counts, not names, so this is a count
+	// rather than a list.
+	UncoveredFunctionCount int
+	// PublicOnly mirrors the --public-only flag, so a template can
+	// choose whether to restrict testing to the file's public API.
+	PublicOnly bool
+	// Exemplars are rendered "// --- path ---\n<contents>" blocks for
+	// well-tested sibling files, found per --few-shot-examples.
+	Exemplars []string
+	// ExistingTests are the names of tests already present in the target
+	// file's #[cfg(test)] module.
+	ExistingTests []string
+}
+
+// Render loads the text/template file at path and executes it against
+// data.
+func Render(path string, data Data) (string, error) {
+	tmpl, err := template.ParseFiles(path)
+	if err != nil {
+		return "", errors.Wrap(err, "parse prompt template")
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "render prompt template")
+	}
+
+	return buf.String(), nil
+}
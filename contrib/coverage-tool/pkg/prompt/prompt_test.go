@@ -0,0 +1,45 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+func TestRenderSubstitutesVariables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompt.tmpl")
+	tmpl := "File at {{.Stats.Path}} ({{.Stats.LinesCovered}}/{{.Stats.LinesTotal}} lines, " +
+		"{{.UncoveredFunctionCount}} uncovered functions):\n\nModule:\n{{.ModuleContext}}\n\nTarget:\n{{.FileContent}}"
+	if err := os.WriteFile(path, []byte(tmpl), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	got, err := Render(path, Data{
+		FileContent:            "pub fn f() {}",
+		ModuleContext:          "// --- sibling.rs ---\npub struct S;",
+		Stats:                  coverage.FileStats{Path: "storage/src/device.rs", LinesCovered: 8, LinesTotal: 10, FunctionsCovered: 1, FunctionsTotal: 3},
+		UncoveredFunctionCount: 2,
+	})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	for _, want := range []string{"storage/src/device.rs", "8/10 lines", "2 uncovered functions", "pub struct S;", "pub fn f() {}"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestRenderErrorsOnMissingFile(t *testing.T) {
+	if _, err := Render(filepath.Join(t.TempDir(), "missing.tmpl"), Data{}); err == nil {
+		t.Error("Render should return an error for a missing template file")
+	}
+}
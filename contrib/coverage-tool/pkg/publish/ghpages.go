@@ -0,0 +1,93 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publish
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// GitHubPagesConfig addresses the branch a dashboard is published to.
+type GitHubPagesConfig struct {
+	RemoteURL string
+	Branch    string
+}
+
+// GitHubPages commits files (destination path within the branch, relative
+// to its root, mapped to local source file path) to cfg.Branch on
+// cfg.RemoteURL and pushes it, creating the branch as an orphan if it
+// doesn't exist yet. It builds the commit in a throwaway worktree so it
+// never touches the caller's current checkout.
+func GitHubPages(cfg GitHubPagesConfig, files map[string]string) error {
+	work, err := os.MkdirTemp("", "coverage-tool-gh-pages-")
+	if err != nil {
+		return errors.Wrap(err, "create publish worktree dir")
+	}
+	defer os.RemoveAll(work)
+
+	if err := runGit(work, "init"); err != nil {
+		return err
+	}
+	if err := runGit(work, "config", "user.name", "coverage-tool"); err != nil {
+		return err
+	}
+	if err := runGit(work, "config", "user.email", "coverage-tool@localhost"); err != nil {
+		return err
+	}
+	if err := runGit(work, "remote", "add", "origin", cfg.RemoteURL); err != nil {
+		return err
+	}
+
+	if err := runGit(work, "fetch", "--depth=1", "origin", cfg.Branch); err == nil {
+		if err := runGit(work, "checkout", "FETCH_HEAD"); err != nil {
+			return err
+		}
+	} else if err := runGit(work, "checkout", "--orphan", cfg.Branch); err != nil {
+		return err
+	}
+
+	for dest, src := range files {
+		if err := copyFile(src, filepath.Join(work, dest)); err != nil {
+			return err
+		}
+	}
+
+	if err := runGit(work, "add", "-A"); err != nil {
+		return err
+	}
+	if err := runGit(work, "commit", "-m", "Publish coverage dashboard"); err != nil {
+		return errors.Wrap(err, "nothing to publish (dashboard unchanged)")
+	}
+	return runGit(work, "push", "origin", "HEAD:"+cfg.Branch)
+}
+
+// runGit runs a git subcommand with dir as its working directory.
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "git %v: %s", args, stderr.String())
+	}
+	return nil
+}
+
+// copyFile copies src to dest, creating dest's parent directories.
+func copyFile(src, dest string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return errors.Wrapf(err, "read %s", src)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return errors.Wrapf(err, "create %s", filepath.Dir(dest))
+	}
+	return errors.Wrapf(os.WriteFile(dest, data, 0o644), "write %s", dest)
+}
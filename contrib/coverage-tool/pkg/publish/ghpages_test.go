@@ -0,0 +1,42 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publish
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitHubPagesCreatesOrphanBranchAndPushes(t *testing.T) {
+	remote := t.TempDir()
+	if err := exec.Command("git", "init", "--bare", remote).Run(); err != nil {
+		t.Fatalf("init bare remote: %v", err)
+	}
+
+	src := filepath.Join(t.TempDir(), "coverage.svg")
+	if err := os.WriteFile(src, []byte("<svg/>"), 0o644); err != nil {
+		t.Fatalf("write source file: %v", err)
+	}
+
+	cfg := GitHubPagesConfig{RemoteURL: remote, Branch: "gh-pages"}
+	if err := GitHubPages(cfg, map[string]string{"badges/coverage.svg": src}); err != nil {
+		t.Fatalf("GitHubPages() = %v, want nil", err)
+	}
+
+	checkout := t.TempDir()
+	if err := exec.Command("git", "clone", "--branch", "gh-pages", remote, checkout).Run(); err != nil {
+		t.Fatalf("clone published branch: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(checkout, "badges", "coverage.svg"))
+	if err != nil {
+		t.Fatalf("read published file: %v", err)
+	}
+	if string(got) != "<svg/>" {
+		t.Errorf("published content = %q, want <svg/>", got)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package publish uploads rendered reports, badges, and history charts to
+// a persistent home (an S3-compatible bucket or a gh-pages branch) so a
+// coverage dashboard survives past CI's artifact retention window.
+package publish
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// S3Config addresses an S3-compatible bucket. Endpoint is the bucket's
+// virtual-hosted-style base URL (e.g. https://my-bucket.s3.us-east-1.amazonaws.com
+// or https://my-bucket.oss-cn-hangzhou.aliyuncs.com for an OSS bucket that
+// speaks the S3 API); Region is used in the SigV4 credential scope and
+// must match the bucket's region regardless of endpoint.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Prefix          string
+}
+
+// key joins cfg.Prefix and name into the object key to upload name under.
+func (c S3Config) key(name string) string {
+	if c.Prefix == "" {
+		return name
+	}
+	return strings.TrimSuffix(c.Prefix, "/") + "/" + strings.TrimPrefix(name, "/")
+}
+
+// PutObject uploads body as key's object, signed with AWS Signature
+// Version 4, so cfg's credentials never need to be shared with the
+// bucket via a public ACL or a separately managed upload proxy.
+func (c S3Config) PutObject(key string, body []byte, contentType string) error {
+	endpoint := strings.TrimSuffix(c.Endpoint, "/")
+	url := fmt.Sprintf("%s/%s", endpoint, c.key(key))
+
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "build S3 put request")
+	}
+
+	host := req.URL.Host
+	amzDate := time.Now().UTC().Format("20060102T150405Z")
+	dateStamp := amzDate[:8]
+	payloadHash := hexSHA256(body)
+
+	req.Header.Set("Host", host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("Content-Type", contentType)
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(req.Header, host)
+	canonicalRequest := strings.Join([]string{
+		http.MethodPut,
+		req.URL.EscapedPath(),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := fmt.Sprintf("%s/%s/s3/aws4_request", dateStamp, c.Region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := signingKey(c.SecretAccessKey, dateStamp, c.Region)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.AccessKeyID, credentialScope, signedHeaders, signature))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "put s3 object %s", key)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("put s3 object %s: server returned status %d", key, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// canonicalizeHeaders builds SigV4's SignedHeaders and CanonicalHeaders
+// from the headers the request actually sets, so the signature always
+// covers exactly what's sent.
+func canonicalizeHeaders(headers http.Header, host string) (signedHeaders, canonicalHeaders string) {
+	names := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	sort.Strings(names)
+
+	values := map[string]string{
+		"host":                 host,
+		"x-amz-content-sha256": headers.Get("X-Amz-Content-Sha256"),
+		"x-amz-date":           headers.Get("X-Amz-Date"),
+	}
+
+	var canonical strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonical, "%s:%s\n", name, strings.TrimSpace(values[name]))
+	}
+
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// signingKey derives SigV4's per-request signing key from secretKey.
+func signingKey(secretKey, dateStamp, region string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, "s3")
+	return hmacSHA256(kService, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package publish
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPutObjectSignsAndSendsBody(t *testing.T) {
+	var gotAuth, gotPath string
+	var gotBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := S3Config{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		Prefix:          "dashboard",
+	}
+
+	if err := cfg.PutObject("coverage.svg", []byte("<svg/>"), "image/svg+xml"); err != nil {
+		t.Fatalf("PutObject() = %v, want nil", err)
+	}
+
+	if gotPath != "/dashboard/coverage.svg" {
+		t.Errorf("request path = %q, want /dashboard/coverage.svg", gotPath)
+	}
+	if string(gotBody) != "<svg/>" {
+		t.Errorf("request body = %q, want <svg/>", gotBody)
+	}
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		t.Errorf("Authorization = %q, want an AWS4-HMAC-SHA256 credential", gotAuth)
+	}
+}
+
+func TestPutObjectErrorsOnNonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	cfg := S3Config{Endpoint: server.URL, Region: "us-east-1", AccessKeyID: "a", SecretAccessKey: "b"}
+	if err := cfg.PutObject("coverage.svg", []byte("x"), "image/svg+xml"); err == nil {
+		t.Error("PutObject() = nil error, want an error on a 403 response")
+	}
+}
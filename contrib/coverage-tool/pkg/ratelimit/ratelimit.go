@@ -0,0 +1,127 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit throttles outgoing API calls to a requests-per-minute
+// and tokens-per-minute budget, persisted to disk so the limit is shared
+// across coverage-tool's separate analyze/generate/report process
+// invocations instead of resetting on every run.
+package ratelimit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// window is how far back usage counts toward the requests/tokens-per-minute
+// budget.
+const window = time.Minute
+
+// usage records a single completed call's token cost.
+type usage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Tokens    int       `json:"tokens"`
+}
+
+// Limiter is a file-backed token-bucket-style rate limiter over a sliding
+// one-minute window. A zero RequestsPerMinute or TokensPerMinute disables
+// that half of the check.
+type Limiter struct {
+	path              string
+	RequestsPerMinute int     `json:"requestsPerMinute"`
+	TokensPerMinute   int     `json:"tokensPerMinute"`
+	Usages            []usage `json:"usages"`
+}
+
+// Open loads the rate limiter state at path, creating an empty one if it
+// does not yet exist.
+func Open(path string, requestsPerMinute, tokensPerMinute int) (*Limiter, error) {
+	l := &Limiter{path: path, RequestsPerMinute: requestsPerMinute, TokensPerMinute: tokensPerMinute}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read rate limiter state")
+	}
+
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, errors.Wrap(err, "parse rate limiter state")
+	}
+	l.path = path
+	l.RequestsPerMinute = requestsPerMinute
+	l.TokensPerMinute = tokensPerMinute
+
+	return l, nil
+}
+
+// Wait blocks until issuing a call estimated at tokens tokens would stay
+// within the requests/tokens-per-minute budget, then records the call.
+func (l *Limiter) Wait(tokens int) error {
+	for {
+		wait := nextAvailable(l.Usages, time.Now(), l.RequestsPerMinute, l.TokensPerMinute, tokens)
+		if wait <= 0 {
+			break
+		}
+		time.Sleep(wait)
+	}
+
+	l.Usages = append(prune(l.Usages, time.Now()), usage{Timestamp: time.Now(), Tokens: tokens})
+	return l.save()
+}
+
+// nextAvailable returns how long to wait before a call of size tokens
+// would fit within requestsPerMinute/tokensPerMinute, given usages so far;
+// 0 means it fits now. A zero limit disables that half of the check.
+func nextAvailable(usages []usage, now time.Time, requestsPerMinute, tokensPerMinute, tokens int) time.Duration {
+	usages = prune(usages, now)
+
+	requestsOK := requestsPerMinute == 0 || len(usages) < requestsPerMinute
+	tokensUsed := 0
+	for _, u := range usages {
+		tokensUsed += u.Tokens
+	}
+	tokensOK := tokensPerMinute == 0 || tokensUsed+tokens <= tokensPerMinute
+
+	if requestsOK && tokensOK {
+		return 0
+	}
+	if len(usages) == 0 {
+		return 0
+	}
+
+	// The oldest usage in the window is the next one to fall out of it;
+	// wait until then and re-check.
+	return usages[0].Timestamp.Add(window).Sub(now)
+}
+
+// prune drops usages older than window relative to now.
+func prune(usages []usage, now time.Time) []usage {
+	var kept []usage
+	for _, u := range usages {
+		if now.Sub(u.Timestamp) < window {
+			kept = append(kept, u)
+		}
+	}
+	return kept
+}
+
+func (l *Limiter) save() error {
+	if l.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return errors.Wrap(err, "create rate limiter directory")
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal rate limiter state")
+	}
+
+	return errors.Wrap(os.WriteFile(l.path, data, 0o644), "write rate limiter state")
+}
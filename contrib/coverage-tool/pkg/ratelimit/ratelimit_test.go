@@ -0,0 +1,84 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextAvailableAllowsUnderBudget(t *testing.T) {
+	now := time.Now()
+	usages := []usage{{Timestamp: now.Add(-10 * time.Second), Tokens: 100}}
+
+	if wait := nextAvailable(usages, now, 5, 1000, 50); wait != 0 {
+		t.Errorf("nextAvailable() = %v, want 0 when under both budgets", wait)
+	}
+}
+
+func TestNextAvailableBlocksOverRequestBudget(t *testing.T) {
+	now := time.Now()
+	usages := []usage{
+		{Timestamp: now.Add(-10 * time.Second), Tokens: 10},
+		{Timestamp: now.Add(-5 * time.Second), Tokens: 10},
+	}
+
+	wait := nextAvailable(usages, now, 2, 0, 10)
+	if wait <= 0 {
+		t.Error("nextAvailable() should be positive once the request budget is exhausted")
+	}
+}
+
+func TestNextAvailableBlocksOverTokenBudget(t *testing.T) {
+	now := time.Now()
+	usages := []usage{{Timestamp: now.Add(-10 * time.Second), Tokens: 900}}
+
+	wait := nextAvailable(usages, now, 0, 1000, 200)
+	if wait <= 0 {
+		t.Error("nextAvailable() should be positive once the token budget would be exceeded")
+	}
+}
+
+func TestNextAvailableZeroLimitsDisableChecks(t *testing.T) {
+	now := time.Now()
+	usages := []usage{{Timestamp: now, Tokens: 1_000_000}}
+
+	if wait := nextAvailable(usages, now, 0, 0, 1_000_000); wait != 0 {
+		t.Errorf("nextAvailable() = %v, want 0 when both limits are 0 (disabled)", wait)
+	}
+}
+
+func TestPruneDropsUsagesOutsideWindow(t *testing.T) {
+	now := time.Now()
+	usages := []usage{
+		{Timestamp: now.Add(-2 * time.Minute), Tokens: 10},
+		{Timestamp: now.Add(-10 * time.Second), Tokens: 10},
+	}
+
+	pruned := prune(usages, now)
+	if len(pruned) != 1 {
+		t.Errorf("prune() kept %d usages, want 1 (only the one inside the window)", len(pruned))
+	}
+}
+
+func TestWaitPersistsAcrossOpen(t *testing.T) {
+	path := t.TempDir() + "/ratelimit.json"
+
+	l, err := Open(path, 0, 0)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := l.Wait(100); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	reopened, err := Open(path, 0, 0)
+	if err != nil {
+		t.Fatalf("re-Open returned error: %v", err)
+	}
+	if len(reopened.Usages) != 1 || reopened.Usages[0].Tokens != 100 {
+		t.Errorf("Usages = %v, want a single persisted usage of 100 tokens", reopened.Usages)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package recommend derives actionable, rule-based recommendations from a
+// run's generation history, so maintainers know what to fix (missing test
+// harnesses, missing dev-dependencies) to make automation more effective
+// instead of re-reading raw failure logs by hand.
+package recommend
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+// consecutiveFailureThreshold is how many consecutive failed attempts on a
+// file trigger a "needs a harness" recommendation.
+const consecutiveFailureThreshold = 3
+
+// harnessKeywords are failure-reason substrings that suggest a file's
+// generation attempts are blocked by something only a human-written test
+// harness would fix, rather than by the model retrying.
+var harnessKeywords = []string{"fuse", "mock", "timeout", "panic"}
+
+// Recommendations derives rule-based recommendations from hist: files
+// whose recent attempts keep failing for the same kind of reason, and
+// crates missing dev-dependencies their failure reasons suggest they need.
+// repoRoot resolves file paths to crate directories on disk.
+func Recommendations(hist *history.Store, repoRoot string) []string {
+	byFile := groupByFile(hist.Attempts)
+
+	var recs []string
+	recs = append(recs, harnessRecommendations(byFile)...)
+	recs = append(recs, devDependencyRecommendations(byFile, repoRoot)...)
+	sort.Strings(recs)
+	return recs
+}
+
+func groupByFile(attempts []history.Attempt) map[string][]history.Attempt {
+	grouped := make(map[string][]history.Attempt)
+	for _, a := range attempts {
+		grouped[a.File] = append(grouped[a.File], a)
+	}
+	return grouped
+}
+
+func harnessRecommendations(byFile map[string][]history.Attempt) []string {
+	var recs []string
+	for file, attempts := range byFile {
+		if len(attempts) < consecutiveFailureThreshold {
+			continue
+		}
+
+		recent := attempts[len(attempts)-consecutiveFailureThreshold:]
+		allFailed := true
+		for _, a := range recent {
+			if a.Success {
+				allFailed = false
+				break
+			}
+		}
+		if !allFailed {
+			continue
+		}
+
+		reason := recent[len(recent)-1].Reason
+		if keyword, ok := matchHarnessKeyword(reason); ok {
+			recs = append(recs, fmt.Sprintf(
+				"%s failed %d times in a row, most recently due to %q — consider adding a test harness for %s",
+				file, len(recent), reason, keyword,
+			))
+		}
+	}
+	return recs
+}
+
+func matchHarnessKeyword(reason string) (string, bool) {
+	lower := strings.ToLower(reason)
+	for _, kw := range harnessKeywords {
+		if strings.Contains(lower, kw) {
+			return kw, true
+		}
+	}
+	return "", false
+}
+
+func devDependencyRecommendations(byFile map[string][]history.Attempt, repoRoot string) []string {
+	seenCrates := make(map[string]bool)
+	var recs []string
+
+	for file, attempts := range byFile {
+		if !anyReasonMentions(attempts, "tempdir") {
+			continue
+		}
+
+		crateDir := findCrateRoot(repoRoot, file)
+		if crateDir == "" || seenCrates[crateDir] {
+			continue
+		}
+		seenCrates[crateDir] = true
+
+		if !cargoTomlHasDevDependency(filepath.Join(repoRoot, crateDir, "Cargo.toml"), "tempfile") {
+			recs = append(recs, fmt.Sprintf(
+				"crate %s has no dev-dependency on tempfile, but %s's failures mention temp directories — add it to unblock generated tests",
+				crateDir, file,
+			))
+		}
+	}
+	return recs
+}
+
+func anyReasonMentions(attempts []history.Attempt, substr string) bool {
+	for _, a := range attempts {
+		if strings.Contains(strings.ToLower(a.Reason), substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCrateRoot walks up from file's directory looking for the nearest
+// Cargo.toml, returning its directory relative to repoRoot, or "" if none
+// is found.
+func findCrateRoot(repoRoot, file string) string {
+	dir := filepath.Dir(filepath.Join(repoRoot, file))
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "Cargo.toml")); err == nil {
+			rel, err := filepath.Rel(repoRoot, dir)
+			if err != nil {
+				return dir
+			}
+			return rel
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// cargoTomlHasDevDependency reports whether the Cargo.toml at path lists
+// dep under [dev-dependencies]. A missing or unreadable Cargo.toml is
+// treated as having the dependency, so a stale history entry never
+// produces a false-alarm recommendation.
+func cargoTomlHasDevDependency(path, dep string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return true
+	}
+
+	inDevDependencies := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inDevDependencies = trimmed == "[dev-dependencies]"
+			continue
+		}
+		if inDevDependencies && strings.HasPrefix(trimmed, dep) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+func TestRecommendationsFlagsRepeatedHarnessFailures(t *testing.T) {
+	dir := t.TempDir()
+	hist, err := history.Open(filepath.Join(dir, "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		_ = hist.Record("storage/src/fuse.rs", false, "FUSE mock server did not respond", 0, 0, 0)
+	}
+
+	recs := Recommendations(hist, dir)
+	if len(recs) != 1 {
+		t.Fatalf("Recommendations() = %v, want exactly one harness recommendation", recs)
+	}
+	if want := "storage/src/fuse.rs"; !strings.Contains(recs[0], want) {
+		t.Errorf("Recommendations()[0] = %q, want it to mention %q", recs[0], want)
+	}
+}
+
+func TestRecommendationsIgnoreUnrelatedFailures(t *testing.T) {
+	dir := t.TempDir()
+	hist, err := history.Open(filepath.Join(dir, "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	_ = hist.Record("storage/src/device.rs", false, "syntax error in generated test", 0, 0, 0)
+	_ = hist.Record("storage/src/device.rs", true, "", 0, 0, 0)
+
+	if recs := Recommendations(hist, dir); len(recs) != 0 {
+		t.Errorf("Recommendations() = %v, want none for a file with no repeated known-cause failures", recs)
+	}
+}
+
+func TestRecommendationsFlagsMissingTempfileDevDependency(t *testing.T) {
+	dir := t.TempDir()
+	crateDir := filepath.Join(dir, "storage")
+	if err := os.MkdirAll(crateDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll returned error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(crateDir, "Cargo.toml"), []byte("[package]\nname = \"storage\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	hist, err := history.Open(filepath.Join(dir, "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	_ = hist.Record("storage/src/device.rs", false, "failed to create tempdir for test fixture", 0, 0, 0)
+
+	recs := Recommendations(hist, dir)
+	if len(recs) != 1 || !strings.Contains(recs[0], "storage") || !strings.Contains(recs[0], "tempfile") {
+		t.Errorf("Recommendations() = %v, want a single tempfile dev-dependency recommendation for crate storage", recs)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redact scans text for common secret and credential patterns
+// before it leaves the machine as part of an llm prompt, replacing any
+// matches with a placeholder and reporting what kind was found, so a test
+// fixture that happens to embed a real-looking token or key doesn't get
+// sent to a third-party API verbatim.
+package redact
+
+import "regexp"
+
+// pattern is one kind of secret Redact looks for.
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// patterns is applied in order; each pattern only sees text already
+// redacted by earlier ones, so an already-replaced placeholder can't be
+// mistaken for a different kind of secret.
+var patterns = []pattern{
+	{"private key block", regexp.MustCompile(`-----BEGIN (?:RSA |EC |OPENSSH |DSA |)PRIVATE KEY-----[\s\S]*?-----END (?:RSA |EC |OPENSSH |DSA |)PRIVATE KEY-----`)},
+	{"AWS access key ID", regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"bearer token", regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]{20,}=*`)},
+	{"API key or secret assignment", regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret|token|password)\b\s*[:=]\s*["']?[A-Za-z0-9\-_/+=]{12,}["']?`)},
+}
+
+// Match records one kind of secret found and how many times, without the
+// secret value itself.
+type Match struct {
+	Name  string
+	Count int
+}
+
+// Redact replaces every occurrence of a known secret pattern in text with
+// "[REDACTED:<name>]", returning the redacted text and a report of what
+// was found, one Match per pattern with at least one hit.
+func Redact(text string) (string, []Match) {
+	var report []Match
+	for _, p := range patterns {
+		matches := p.re.FindAllString(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		text = p.re.ReplaceAllString(text, "[REDACTED:"+p.name+"]")
+		report = append(report, Match{Name: p.name, Count: len(matches)})
+	}
+	return text, report
+}
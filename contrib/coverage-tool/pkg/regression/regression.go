@@ -0,0 +1,109 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package regression drives the checkout-test-checkout dance used to prove
+// that a generated regression test actually reproduces a bug: it must fail
+// against the commit before a fix and pass against the commit that fixed
+// it.
+package regression
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Verifier verifies generated regression tests against commits in
+// RepoRoot. Each check runs in its own disposable `git worktree`, so
+// RepoRoot's checked-out branch and working tree are never touched, even
+// if they are dirty.
+type Verifier struct {
+	RepoRoot string
+}
+
+// Diff returns the full diff introduced by sha, as `git show` would print
+// it, for use as model context.
+func (v Verifier) Diff(sha string) (string, error) {
+	out, err := v.git(v.RepoRoot, "show", sha)
+	if err != nil {
+		return "", errors.Wrapf(err, "diff commit %s", sha)
+	}
+	return out, nil
+}
+
+// VerifyFix appends testCode to file and checks that the resulting test
+// suite fails at sha's parent (before the fix) and passes at sha (after
+// the fix). Both checks run in their own throwaway worktree, so RepoRoot
+// itself is never checked out or modified.
+func (v Verifier) VerifyFix(sha, file, testCode string) (bool, error) {
+	passedBeforeFix, err := v.runAt(sha+"^", file, testCode)
+	if err != nil {
+		return false, errors.Wrap(err, "run test before fix")
+	}
+	if passedBeforeFix {
+		// The test doesn't reproduce the bug: it already passes before
+		// the fix, so it can't be reporting a regression.
+		return false, nil
+	}
+
+	passedAfterFix, err := v.runAt(sha, file, testCode)
+	if err != nil {
+		return false, errors.Wrap(err, "run test after fix")
+	}
+
+	return passedAfterFix, nil
+}
+
+// runAt checks out ref into a disposable worktree, appends testCode to
+// file there, runs the workspace test suite, and reports whether the
+// tests passed. The worktree (and its registration in RepoRoot's git
+// metadata) is removed before returning.
+func (v Verifier) runAt(ref, file, testCode string) (bool, error) {
+	parent, err := os.MkdirTemp("", "coverage-tool-regression-")
+	if err != nil {
+		return false, errors.Wrap(err, "create temp dir for worktree")
+	}
+	defer os.RemoveAll(parent)
+
+	worktree := filepath.Join(parent, "wt")
+	if _, err := v.git(v.RepoRoot, "worktree", "add", "--detach", "--force", worktree, ref); err != nil {
+		return false, errors.Wrapf(err, "add worktree for %s", ref)
+	}
+	defer func() {
+		_, _ = v.git(v.RepoRoot, "worktree", "remove", "--force", worktree)
+	}()
+
+	if err := appendToFile(filepath.Join(worktree, file), testCode); err != nil {
+		return false, err
+	}
+
+	cmd := exec.Command("cargo", "test")
+	cmd.Dir = worktree
+	err = cmd.Run()
+
+	return err == nil, nil
+}
+
+func (v Verifier) git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), errors.Wrapf(err, "git %v: %s", args, out)
+	}
+	return string(out), nil
+}
+
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "open %s for appending", path)
+	}
+	defer f.Close()
+
+	_, err = f.WriteString("\n" + content + "\n")
+	return errors.Wrapf(err, "append test code to %s", path)
+}
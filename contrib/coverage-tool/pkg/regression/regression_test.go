@@ -0,0 +1,107 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package regression
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initRepoWithFixCommit creates a throwaway git repo with a "buggy"
+// commit followed by a "fix" commit to file, and returns the repo root
+// and the fix commit's sha.
+func initRepoWithFixCommit(t *testing.T) (repoRoot, fixSHA string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "test")
+
+	file := filepath.Join(dir, "lib.rs")
+	if err := os.WriteFile(file, []byte("pub fn buggy() -> i32 {\n    1\n}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	run("add", "lib.rs")
+	run("commit", "-q", "-m", "introduce bug")
+
+	if err := os.WriteFile(file, []byte("pub fn buggy() -> i32 {\n    2\n}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	run("add", "lib.rs")
+	run("commit", "-q", "-m", "fix bug")
+
+	sha := strings.TrimSpace(run("rev-parse", "HEAD"))
+	return dir, sha
+}
+
+func TestVerifyFixDoesNotTouchRepoRoot(t *testing.T) {
+	repoRoot, fixSHA := initRepoWithFixCommit(t)
+
+	v := Verifier{RepoRoot: repoRoot}
+	beforeHead, err := v.git(repoRoot, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatalf("git rev-parse returned error: %v", err)
+	}
+	beforeContent, err := os.ReadFile(filepath.Join(repoRoot, "lib.rs"))
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+
+	// cargo is not expected to be installed in this environment; VerifyFix
+	// should still complete without error and without mutating RepoRoot.
+	if _, err := v.VerifyFix(fixSHA, "lib.rs", "// added by generator"); err != nil {
+		t.Fatalf("VerifyFix returned error: %v", err)
+	}
+
+	afterHead, err := v.git(repoRoot, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatalf("git rev-parse returned error: %v", err)
+	}
+	if afterHead != beforeHead {
+		t.Errorf("RepoRoot HEAD changed: before %q, after %q", beforeHead, afterHead)
+	}
+
+	afterContent, err := os.ReadFile(filepath.Join(repoRoot, "lib.rs"))
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if string(afterContent) != string(beforeContent) {
+		t.Errorf("RepoRoot working tree file changed:\nbefore: %q\nafter: %q", beforeContent, afterContent)
+	}
+
+	worktrees, err := v.git(repoRoot, "worktree", "list")
+	if err != nil {
+		t.Fatalf("git worktree list returned error: %v", err)
+	}
+	if strings.Count(strings.TrimSpace(worktrees), "\n") != 0 {
+		t.Errorf("expected only the main worktree to remain, got:\n%s", worktrees)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	repoRoot, fixSHA := initRepoWithFixCommit(t)
+
+	diff, err := (Verifier{RepoRoot: repoRoot}).Diff(fixSHA)
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+	if !strings.Contains(diff, "fix bug") {
+		t.Errorf("Diff should include the commit message, got:\n%s", diff)
+	}
+}
@@ -0,0 +1,448 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package report renders coverage-tool run results for humans.
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+//go:embed report.html.tmpl
+var htmlTemplateSource string
+
+// Result summarizes a single file's coverage improvement run.
+type Result struct {
+	File string
+	// FileURL is a GitHub permalink to File at the commit the report was
+	// generated for, if the caller resolved one, so a reviewer can jump
+	// straight to the source instead of locating it in a checkout.
+	FileURL  string
+	Before   coverage.FileStats
+	After    coverage.FileStats
+	Model    string
+	Success  bool
+	Warnings []string
+	// Diff is the unified diff of the accepted test's insertion into File,
+	// if the caller has one (e.g. candidate.Evaluator's LogDir output).
+	// Markdown embeds it in a collapsed <details> section (GitHub renders
+	// <details> in both rendered markdown and PR comment bodies); HTML
+	// includes it verbatim in its own section.
+	Diff string
+	// Attempts is File's generation history, if the caller loaded one from
+	// a history.Store. JSON includes the full list; Markdown and HTML
+	// render it as an expenses section (per-attempt tokens and cost, plus a
+	// total), since finance wants visibility into what generation costs
+	// without parsing the JSON report.
+	Attempts []history.Attempt
+	// PromptTokens and CompletionTokens are the LLM token usage accumulated
+	// generating File's accepted candidate, if the caller tracked it; only
+	// JSON includes them.
+	PromptTokens     int
+	CompletionTokens int
+	// Lang selects the section template Markdown renders with, e.g. "en"
+	// or "zh". Defaults to "en" when empty.
+	Lang string
+	// Crates is the workspace's per-crate coverage rollup, if the caller
+	// computed one; maintainers reviewing a single file's report often
+	// also want to see how the crate it lives in is doing overall.
+	Crates []CrateDelta
+	// Uncovered lists File's functions still lacking coverage after this
+	// run, if the caller computed one, so a reviewer knows exactly what
+	// manual follow-up remains.
+	Uncovered []UncoveredFunctionRef
+}
+
+// UncoveredFunctionRef is one function in Result.File with no execution
+// coverage, with an optional GitHub permalink to its declaration.
+type UncoveredFunctionRef struct {
+	Name string `json:"name"`
+	Line int    `json:"line"`
+	URL  string `json:"url,omitempty"`
+}
+
+// CrateDelta is one Cargo workspace member's coverage change between a
+// report's Before and After snapshots.
+type CrateDelta struct {
+	Crate         string  `json:"crate"`
+	BeforePercent float64 `json:"beforePercent"`
+	AfterPercent  float64 `json:"afterPercent"`
+}
+
+// Delta returns the change in the crate's line coverage percentage.
+func (c CrateDelta) Delta() float64 {
+	return c.AfterPercent - c.BeforePercent
+}
+
+// labels holds the translated section labels Markdown and HTML render
+// with. Fields are exported so html/template can read them by reflection.
+type labels struct {
+	Title             string
+	ConsolidatedTitle string
+	Model             string
+	Before            string
+	After             string
+	Delta             string
+	Warnings          string
+	Diff              string
+	Drilldown         string
+	Crates            string
+	Uncovered         string
+	Expenses          string
+	ExpensesTotal     string
+}
+
+var templates = map[string]labels{
+	"en": {
+		Title:             "Coverage report: %s",
+		ConsolidatedTitle: "Coverage report",
+		Model:             "Model",
+		Before:            "Before",
+		After:             "After",
+		Delta:             "Delta",
+		Warnings:          "Warnings",
+		Diff:              "Inserted test",
+		Drilldown:         "Coverage drilldown",
+		Crates:            "Per-crate coverage",
+		Uncovered:         "Uncovered functions",
+		Expenses:          "Expenses",
+		ExpensesTotal:     "Total",
+	},
+	"zh": {
+		Title:             "覆盖率报告: %s",
+		ConsolidatedTitle: "覆盖率报告",
+		Model:             "模型",
+		Before:            "变更前",
+		After:             "变更后",
+		Delta:             "变化",
+		Warnings:          "警告",
+		Diff:              "新增测试",
+		Drilldown:         "覆盖率详情",
+		Crates:            "各 crate 覆盖率",
+		Uncovered:         "未覆盖函数",
+		Expenses:          "开销",
+		ExpensesTotal:     "总计",
+	},
+}
+
+// templateFor returns result's translated section labels ("en" or "zh";
+// defaults to "en" when unset or unrecognized).
+func templateFor(lang string) labels {
+	tpl, ok := templates[lang]
+	if !ok {
+		tpl = templates["en"]
+	}
+	return tpl
+}
+
+// Markdown renders result as a markdown report, translated per result.Lang
+// ("en" or "zh"; defaults to "en" when unset or unrecognized).
+func Markdown(result Result) string {
+	tpl := templateFor(result.Lang)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# "+tpl.Title+"\n\n", titleTarget(result))
+	writeResultBody(&buf, tpl, result)
+	return buf.String()
+}
+
+// titleTarget renders result.File as a markdown link to result.FileURL, if
+// set, so the report's own heading doubles as a permalink to the source.
+func titleTarget(result Result) string {
+	if result.FileURL == "" {
+		return result.File
+	}
+	return fmt.Sprintf("[%s](%s)", result.File, result.FileURL)
+}
+
+// writeResultBody renders result's before/after summary and its optional
+// diff/crates/uncovered/warnings sections, everything but the title, so
+// Markdown and MarkdownConsolidated can share the per-file rendering while
+// choosing their own heading levels around it.
+func writeResultBody(buf *bytes.Buffer, tpl labels, result Result) {
+	fmt.Fprintf(buf, "- %s: %s\n", tpl.Model, result.Model)
+	fmt.Fprintf(buf, "- %s: %.2f%%\n", tpl.Before, result.Before.Percent())
+	fmt.Fprintf(buf, "- %s: %.2f%%\n", tpl.After, result.After.Percent())
+	fmt.Fprintf(buf, "- %s: %+.2f%%\n", tpl.Delta, result.After.Percent()-result.Before.Percent())
+
+	if result.Diff != "" {
+		fmt.Fprintf(buf, "\n<details>\n<summary>%s</summary>\n\n```diff\n%s\n```\n\n</details>\n", tpl.Diff, strings.TrimRight(result.Diff, "\n"))
+	}
+
+	if len(result.Crates) > 0 {
+		fmt.Fprintf(buf, "\n## %s\n\n", tpl.Crates)
+		fmt.Fprintf(buf, "| Crate | %s | %s | %s |\n|---|---|---|---|\n", tpl.Before, tpl.After, tpl.Delta)
+		for _, c := range result.Crates {
+			fmt.Fprintf(buf, "| %s | %.2f%% | %.2f%% | %+.2f%% |\n", c.Crate, c.BeforePercent, c.AfterPercent, c.Delta())
+		}
+	}
+
+	if len(result.Uncovered) > 0 {
+		fmt.Fprintf(buf, "\n## %s\n\n", tpl.Uncovered)
+		for _, fn := range result.Uncovered {
+			if fn.URL != "" {
+				fmt.Fprintf(buf, "- [`%s`](%s) (line %d)\n", fn.Name, fn.URL, fn.Line)
+			} else {
+				fmt.Fprintf(buf, "- `%s` (line %d)\n", fn.Name, fn.Line)
+			}
+		}
+	}
+
+	if len(result.Attempts) > 0 {
+		fmt.Fprintf(buf, "\n## %s\n\n", tpl.Expenses)
+		fmt.Fprintf(buf, "| Time | Success | Prompt tokens | Completion tokens | Cost (USD) |\n|---|---|---|---|---|\n")
+		var totalPrompt, totalCompletion int
+		var totalCost float64
+		for _, a := range result.Attempts {
+			fmt.Fprintf(buf, "| %s | %v | %d | %d | $%.4f |\n", a.Timestamp.Format("2006-01-02 15:04"), a.Success, a.PromptTokens, a.CompletionTokens, a.CostUSD)
+			totalPrompt += a.PromptTokens
+			totalCompletion += a.CompletionTokens
+			totalCost += a.CostUSD
+		}
+		fmt.Fprintf(buf, "| **%s** | | %d | %d | $%.4f |\n", tpl.ExpensesTotal, totalPrompt, totalCompletion, totalCost)
+	}
+
+	if len(result.Warnings) > 0 {
+		fmt.Fprintf(buf, "\n## %s\n\n", tpl.Warnings)
+		for _, w := range result.Warnings {
+			fmt.Fprintf(buf, "- %s\n", w)
+		}
+	}
+}
+
+// WriteMarkdown renders result and writes it to path.
+func WriteMarkdown(path string, result Result) error {
+	return errors.Wrap(os.WriteFile(path, []byte(Markdown(result)), 0o644), "write report")
+}
+
+// ConsolidatedResult is several files' coverage improvement runs, combined
+// into a single report so a multi-file pipeline run doesn't overwrite one
+// file's report with the next.
+type ConsolidatedResult struct {
+	Results []Result `json:"results"`
+}
+
+// Upsert adds result to c, replacing any existing entry for the same File so
+// re-running a file (e.g. a retry) updates its section instead of
+// duplicating it.
+func (c *ConsolidatedResult) Upsert(result Result) {
+	for i, existing := range c.Results {
+		if existing.File == result.File {
+			c.Results[i] = result
+			return
+		}
+	}
+	c.Results = append(c.Results, result)
+}
+
+// LoadConsolidatedState reads the consolidated report state previously
+// written by WriteConsolidatedState, or an empty ConsolidatedResult if path
+// doesn't exist yet (a run's first file).
+func LoadConsolidatedState(path string) (ConsolidatedResult, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ConsolidatedResult{}, nil
+	}
+	if err != nil {
+		return ConsolidatedResult{}, errors.Wrap(err, "read consolidated report state")
+	}
+
+	var c ConsolidatedResult
+	if err := json.Unmarshal(data, &c); err != nil {
+		return ConsolidatedResult{}, errors.Wrap(err, "parse consolidated report state")
+	}
+	return c, nil
+}
+
+// WriteConsolidatedState persists c to path so a later invocation (the next
+// file in the same run) can load and extend it.
+func WriteConsolidatedState(path string, c ConsolidatedResult) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal consolidated report state")
+	}
+	return errors.Wrap(os.WriteFile(path, data, 0o644), "write consolidated report state")
+}
+
+// MarkdownConsolidated renders c as a single markdown report: a summary
+// table of every file's before/after/delta, followed by each file's own
+// section (in the same format Markdown renders for a single file).
+// Translated per each Result's Lang, falling back to "en".
+func MarkdownConsolidated(c ConsolidatedResult) string {
+	lang := ""
+	if len(c.Results) > 0 {
+		lang = c.Results[0].Lang
+	}
+	tpl := templateFor(lang)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n\n", tpl.ConsolidatedTitle)
+	fmt.Fprintf(&buf, "| File | %s | %s | %s |\n|---|---|---|---|\n", tpl.Before, tpl.After, tpl.Delta)
+	for _, r := range c.Results {
+		fmt.Fprintf(&buf, "| %s | %.2f%% | %.2f%% | %+.2f%% |\n", r.File, r.Before.Percent(), r.After.Percent(), r.After.Percent()-r.Before.Percent())
+	}
+
+	for _, r := range c.Results {
+		fmt.Fprintf(&buf, "\n## %s\n\n", titleTarget(r))
+		writeResultBody(&buf, templateFor(r.Lang), r)
+	}
+
+	return buf.String()
+}
+
+// WriteMarkdownConsolidated renders c and writes it to path.
+func WriteMarkdownConsolidated(path string, c ConsolidatedResult) error {
+	return errors.Wrap(os.WriteFile(path, []byte(MarkdownConsolidated(c)), 0o644), "write consolidated report")
+}
+
+// htmlData is the (necessarily exported) view of Result and its labels that
+// htmlTemplate executes against.
+type htmlData struct {
+	Result
+	Labels       labels
+	DeltaPercent float64
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlTemplateSource))
+
+// HTML renders result as a standalone HTML page, with before/after and
+// per-function/region/line coverage drilldown tables, the inserted test
+// diff (if result.Diff is set), and any warnings, translated per
+// result.Lang ("en" or "zh"; defaults to "en" when unset or unrecognized).
+// The page is self-contained (inline CSS, no external resources), so it can
+// be published as-is via GitHub Pages.
+func HTML(result Result) (string, error) {
+	tpl, ok := templates[result.Lang]
+	if !ok {
+		tpl = templates["en"]
+	}
+
+	var buf bytes.Buffer
+	data := htmlData{
+		Result:       result,
+		Labels:       tpl,
+		DeltaPercent: result.After.Percent() - result.Before.Percent(),
+	}
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "render html report")
+	}
+	return buf.String(), nil
+}
+
+// WriteHTML renders result as HTML and writes it to path.
+func WriteHTML(path string, result Result) error {
+	html, err := HTML(result)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(path, []byte(html), 0o644), "write report")
+}
+
+// jsonReportVersion is bumped whenever jsonDocument's shape changes in a
+// backward-incompatible way, so automation consuming it can detect and
+// handle the change instead of silently misparsing a new field layout.
+const jsonReportVersion = 1
+
+// jsonDocument is the stable, tagged shape JSON serializes Result to, so
+// external tooling (dashboards, bots) has a versioned contract to code
+// against rather than Result's Go field names.
+type jsonDocument struct {
+	Version          int                    `json:"version"`
+	File             string                 `json:"file"`
+	FileURL          string                 `json:"fileUrl,omitempty"`
+	Model            string                 `json:"model"`
+	Success          bool                   `json:"success"`
+	Before           coverage.FileStats     `json:"before"`
+	After            coverage.FileStats     `json:"after"`
+	DeltaPercent     float64                `json:"deltaPercent"`
+	Warnings         []string               `json:"warnings,omitempty"`
+	Attempts         []history.Attempt      `json:"attempts,omitempty"`
+	PromptTokens     int                    `json:"promptTokens,omitempty"`
+	CompletionTokens int                    `json:"completionTokens,omitempty"`
+	Crates           []CrateDelta           `json:"crates,omitempty"`
+	Uncovered        []UncoveredFunctionRef `json:"uncovered,omitempty"`
+}
+
+// toJSONDocument converts result to its versioned JSON shape, shared by JSON
+// and consolidatedJSONDocument.
+func toJSONDocument(result Result) jsonDocument {
+	return jsonDocument{
+		Version:          jsonReportVersion,
+		File:             result.File,
+		FileURL:          result.FileURL,
+		Model:            result.Model,
+		Success:          result.Success,
+		Before:           result.Before,
+		After:            result.After,
+		DeltaPercent:     result.After.Percent() - result.Before.Percent(),
+		Warnings:         result.Warnings,
+		Attempts:         result.Attempts,
+		PromptTokens:     result.PromptTokens,
+		CompletionTokens: result.CompletionTokens,
+		Crates:           result.Crates,
+		Uncovered:        result.Uncovered,
+	}
+}
+
+// JSON renders result as a versioned, machine-readable JSON document with
+// all before/after metrics, validation attempts, model, and token usage, so
+// automation can consume a run's outcome without scraping markdown or HTML.
+func JSON(result Result) (string, error) {
+	data, err := json.MarshalIndent(toJSONDocument(result), "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "marshal json report")
+	}
+	return string(data), nil
+}
+
+// WriteJSON renders result as JSON and writes it to path.
+func WriteJSON(path string, result Result) error {
+	data, err := JSON(result)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(path, []byte(data), 0o644), "write report")
+}
+
+// consolidatedJSONDocument is the stable, tagged shape JSON serializes
+// ConsolidatedResult to, mirroring jsonDocument's per-file contract.
+type consolidatedJSONDocument struct {
+	Version int            `json:"version"`
+	Files   []jsonDocument `json:"files"`
+}
+
+// JSONConsolidated renders c as a versioned, machine-readable JSON document
+// listing every file's report.
+func JSONConsolidated(c ConsolidatedResult) (string, error) {
+	doc := consolidatedJSONDocument{Version: jsonReportVersion}
+	for _, r := range c.Results {
+		doc.Files = append(doc.Files, toJSONDocument(r))
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "marshal consolidated json report")
+	}
+	return string(data), nil
+}
+
+// WriteJSONConsolidated renders c as JSON and writes it to path.
+func WriteJSONConsolidated(path string, c ConsolidatedResult) error {
+	data, err := JSONConsolidated(c)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(path, []byte(data), 0o644), "write consolidated report")
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+func TestMarkdownTranslatesSectionLabels(t *testing.T) {
+	result := Result{File: "storage/src/device.rs", Lang: "zh"}
+
+	md := Markdown(result)
+	if want := "# 覆盖率报告: storage/src/device.rs"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+}
+
+func TestMarkdownDefaultsToEnglish(t *testing.T) {
+	result := Result{File: "storage/src/device.rs"}
+
+	md := Markdown(result)
+	if want := "# Coverage report: storage/src/device.rs"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+}
+
+func TestMarkdownLinksTitleWhenFileURLSet(t *testing.T) {
+	result := Result{File: "storage/src/device.rs", FileURL: "https://github.com/bergwolf/nydus/blob/abc123/storage/src/device.rs"}
+
+	md := Markdown(result)
+	if want := "# Coverage report: [storage/src/device.rs](https://github.com/bergwolf/nydus/blob/abc123/storage/src/device.rs)"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+}
+
+func TestMarkdownIncludesCollapsedDiff(t *testing.T) {
+	result := Result{
+		File: "storage/src/device.rs",
+		Diff: "+#[test]\n+fn test_read() {}\n",
+	}
+
+	md := Markdown(result)
+	if !strings.Contains(md, "<details>") || !strings.Contains(md, "</details>") {
+		t.Errorf("Markdown() = %q, want a <details> section", md)
+	}
+	if !strings.Contains(md, "+fn test_read() {}") {
+		t.Errorf("Markdown() = %q, want it to contain the diff body", md)
+	}
+}
+
+func TestMarkdownOmitsDiffSectionWhenUnset(t *testing.T) {
+	result := Result{File: "storage/src/device.rs"}
+
+	md := Markdown(result)
+	if strings.Contains(md, "<details>") {
+		t.Errorf("Markdown() = %q, want no <details> section when Diff is unset", md)
+	}
+}
+
+func TestMarkdownIncludesCrateRollup(t *testing.T) {
+	result := Result{
+		File:   "storage/src/device.rs",
+		Crates: []CrateDelta{{Crate: "storage", BeforePercent: 60, AfterPercent: 65}},
+	}
+
+	md := Markdown(result)
+	if want := "## Per-crate coverage"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+	if want := "| storage | 60.00% | 65.00% | +5.00% |"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+}
+
+func TestMarkdownConsolidatedIncludesSummaryAndSections(t *testing.T) {
+	c := ConsolidatedResult{
+		Results: []Result{
+			{File: "storage/src/device.rs", Before: coverage.FileStats{LinesCovered: 6, LinesTotal: 10}, After: coverage.FileStats{LinesCovered: 8, LinesTotal: 10}},
+			{File: "rafs/src/metadata.rs", Before: coverage.FileStats{LinesCovered: 2, LinesTotal: 10}, After: coverage.FileStats{LinesCovered: 2, LinesTotal: 10}},
+		},
+	}
+
+	md := MarkdownConsolidated(c)
+	if want := "| storage/src/device.rs | 60.00% | 80.00% | +20.00% |"; !strings.Contains(md, want) {
+		t.Errorf("MarkdownConsolidated() = %q, want it to contain %q", md, want)
+	}
+	if want := "## storage/src/device.rs"; !strings.Contains(md, want) {
+		t.Errorf("MarkdownConsolidated() = %q, want a per-file section for %q", md, want)
+	}
+	if want := "## rafs/src/metadata.rs"; !strings.Contains(md, want) {
+		t.Errorf("MarkdownConsolidated() = %q, want a per-file section for %q", md, want)
+	}
+}
+
+func TestConsolidatedResultUpsertReplacesSameFile(t *testing.T) {
+	var c ConsolidatedResult
+	c.Upsert(Result{File: "a.rs", Before: coverage.FileStats{LinesTotal: 10}})
+	c.Upsert(Result{File: "b.rs", Before: coverage.FileStats{LinesTotal: 10}})
+	c.Upsert(Result{File: "a.rs", Before: coverage.FileStats{LinesCovered: 5, LinesTotal: 10}})
+
+	if len(c.Results) != 2 {
+		t.Fatalf("len(c.Results) = %d, want 2", len(c.Results))
+	}
+	if c.Results[0].Before.LinesCovered != 5 {
+		t.Errorf("c.Results[0].Before.LinesCovered = %d, want 5 (retry should replace, not duplicate)", c.Results[0].Before.LinesCovered)
+	}
+}
+
+func TestLoadConsolidatedStateMissingFileReturnsEmpty(t *testing.T) {
+	c, err := LoadConsolidatedState("/nonexistent/coverage_report.md.state.json")
+	if err != nil {
+		t.Fatalf("LoadConsolidatedState() error = %v", err)
+	}
+	if len(c.Results) != 0 {
+		t.Errorf("c.Results = %v, want empty for a missing state file", c.Results)
+	}
+}
+
+func TestConsolidatedStateRoundTrips(t *testing.T) {
+	path := t.TempDir() + "/state.json"
+
+	var c ConsolidatedResult
+	c.Upsert(Result{File: "a.rs", Model: "gpt-5"})
+	if err := WriteConsolidatedState(path, c); err != nil {
+		t.Fatalf("WriteConsolidatedState() error = %v", err)
+	}
+
+	loaded, err := LoadConsolidatedState(path)
+	if err != nil {
+		t.Fatalf("LoadConsolidatedState() error = %v", err)
+	}
+	if len(loaded.Results) != 1 || loaded.Results[0].File != "a.rs" || loaded.Results[0].Model != "gpt-5" {
+		t.Errorf("loaded = %+v, want the written result back", loaded)
+	}
+}
+
+func TestJSONConsolidatedIncludesEveryFile(t *testing.T) {
+	c := ConsolidatedResult{Results: []Result{{File: "a.rs"}, {File: "b.rs"}}}
+
+	out, err := JSONConsolidated(c)
+	if err != nil {
+		t.Fatalf("JSONConsolidated() error = %v", err)
+	}
+
+	var doc consolidatedJSONDocument
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("json.Unmarshal(JSONConsolidated() output) error = %v", err)
+	}
+	if len(doc.Files) != 2 {
+		t.Errorf("len(doc.Files) = %d, want 2", len(doc.Files))
+	}
+}
+
+func TestMarkdownIncludesExpensesSection(t *testing.T) {
+	result := Result{
+		File: "storage/src/device.rs",
+		Attempts: []history.Attempt{
+			{Success: false, PromptTokens: 1000, CompletionTokens: 200, CostUSD: 0.01},
+			{Success: true, PromptTokens: 1200, CompletionTokens: 300, CostUSD: 0.015},
+		},
+	}
+
+	md := Markdown(result)
+	if want := "## Expenses"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain %q", md, want)
+	}
+	if want := "| **Total** | | 2200 | 500 | $0.0250 |"; !strings.Contains(md, want) {
+		t.Errorf("Markdown() = %q, want it to contain the total row %q", md, want)
+	}
+}
+
+func TestMarkdownOmitsExpensesSectionWhenUnset(t *testing.T) {
+	md := Markdown(Result{File: "storage/src/device.rs"})
+	if strings.Contains(md, "## Expenses") {
+		t.Errorf("Markdown() = %q, want no Expenses section when Attempts is unset", md)
+	}
+}
+
+func TestHTMLIncludesDrilldownAndDiff(t *testing.T) {
+	result := Result{
+		File:   "storage/src/device.rs",
+		Before: coverage.FileStats{FunctionsCovered: 1, FunctionsTotal: 4},
+		After:  coverage.FileStats{FunctionsCovered: 3, FunctionsTotal: 4},
+		Diff:   "+#[test]\n+fn it_works() {}\n",
+	}
+
+	html, err := HTML(result)
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	for _, want := range []string{"Coverage report: storage/src/device.rs", "1/4", "3/4", "it_works"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML() = %q, want it to contain %q", html, want)
+		}
+	}
+}
+
+func TestHTMLLinksTitleWhenFileURLSet(t *testing.T) {
+	result := Result{File: "storage/src/device.rs", FileURL: "https://github.com/bergwolf/nydus/blob/abc123/storage/src/device.rs"}
+
+	html, err := HTML(result)
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	if want := `<a href="https://github.com/bergwolf/nydus/blob/abc123/storage/src/device.rs">`; !strings.Contains(html, want) {
+		t.Errorf("HTML() = %q, want it to contain %q", html, want)
+	}
+}
+
+func TestHTMLIncludesExpensesSection(t *testing.T) {
+	result := Result{
+		File:     "storage/src/device.rs",
+		Attempts: []history.Attempt{{Success: true, PromptTokens: 500, CompletionTokens: 100, CostUSD: 0.005}},
+	}
+
+	html, err := HTML(result)
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	if !strings.Contains(html, "Expenses") || !strings.Contains(html, "$0.0050") {
+		t.Errorf("HTML() = %q, want an expenses section with the attempt's cost", html)
+	}
+}
+
+func TestHTMLOmitsDiffSectionWhenUnset(t *testing.T) {
+	html, err := HTML(Result{File: "storage/src/device.rs"})
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	if strings.Contains(html, "Inserted test") {
+		t.Errorf("HTML() = %q, want no diff section when Diff is unset", html)
+	}
+}
+
+func TestJSONIncludesVersionedFields(t *testing.T) {
+	result := Result{
+		File:             "storage/src/device.rs",
+		Model:            "gpt-5",
+		Before:           coverage.FileStats{LinesCovered: 1, LinesTotal: 4},
+		After:            coverage.FileStats{LinesCovered: 3, LinesTotal: 4},
+		Attempts:         []history.Attempt{{File: "storage/src/device.rs", Success: true}},
+		PromptTokens:     100,
+		CompletionTokens: 50,
+	}
+
+	out, err := JSON(result)
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+
+	var doc jsonDocument
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("json.Unmarshal(JSON() output) error = %v", err)
+	}
+	if doc.Version != jsonReportVersion {
+		t.Errorf("doc.Version = %d, want %d", doc.Version, jsonReportVersion)
+	}
+	if len(doc.Attempts) != 1 || doc.PromptTokens != 100 || doc.CompletionTokens != 50 {
+		t.Errorf("doc = %+v, want attempts/tokens carried through", doc)
+	}
+	if doc.DeltaPercent != 50.0 {
+		t.Errorf("doc.DeltaPercent = %v, want 50.0", doc.DeltaPercent)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sarif renders coverage-tool findings as a SARIF v2.1.0 log, so
+// GitHub code scanning can surface them inline on PR diffs.
+package sarif
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	schemaURI     = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	schemaVersion = "2.1.0"
+)
+
+// Finding is a single issue to report at a specific file/line.
+type Finding struct {
+	RuleID  string
+	Message string
+	File    string
+	Line    int
+}
+
+// log mirrors the subset of the SARIF v2.1.0 schema coverage-tool emits.
+type log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool    tool     `json:"tool"`
+	Results []result `json:"results"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Rules   []rule `json:"rules"`
+}
+
+type rule struct {
+	ID string `json:"id"`
+}
+
+type result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   message    `json:"message"`
+	Locations []location `json:"locations"`
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type location struct {
+	PhysicalLocation physicalLocation `json:"physicalLocation"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+	Region           region           `json:"region"`
+}
+
+type artifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type region struct {
+	StartLine int `json:"startLine"`
+}
+
+// Write renders findings as a SARIF v2.1.0 log naming toolName/toolVersion
+// as the analysis tool, and writes it to path.
+func Write(path, toolName, toolVersion string, findings []Finding) error {
+	seenRules := make(map[string]bool)
+	var rules []rule
+	var results []result
+	for _, f := range findings {
+		if !seenRules[f.RuleID] {
+			seenRules[f.RuleID] = true
+			rules = append(rules, rule{ID: f.RuleID})
+		}
+		results = append(results, result{
+			RuleID:  f.RuleID,
+			Level:   "warning",
+			Message: message{Text: f.Message},
+			Locations: []location{{
+				PhysicalLocation: physicalLocation{
+					ArtifactLocation: artifactLocation{URI: f.File},
+					Region:           region{StartLine: f.Line},
+				},
+			}},
+		})
+	}
+
+	l := log{
+		Schema:  schemaURI,
+		Version: schemaVersion,
+		Runs: []run{{
+			Tool:    tool{Driver: driver{Name: toolName, Version: toolVersion, Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal sarif log")
+	}
+	return errors.Wrap(os.WriteFile(path, data, 0o644), "write sarif log")
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sarif
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	findings := []Finding{
+		{RuleID: "uncovered-public-function", Message: `public function "read" has no test coverage`, File: "storage/src/device.rs", Line: 42},
+	}
+
+	if err := Write(path, "coverage-tool", "0.0.0-dev", findings); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	for _, want := range []string{`"version": "2.1.0"`, `"ruleId": "uncovered-public-function"`, `"uri": "storage/src/device.rs"`, `"startLine": 42`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("sarif output = %s, want it to contain %q", data, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selector
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+// generatedTags mark a file as generated the moment a single line
+// contains one: conventional tool-added headers like "@generated" or a
+// bindgen banner comment.
+var generatedTags = []string{
+	"@generated",
+	"automatically generated by rust-bindgen",
+}
+
+// generatedMarkers are substrings whose density, not mere presence,
+// indicates bindgen FFI bindings or include!'d generated code: a normal
+// file may have one extern "C" block, but a file that is mostly them is
+// a shim a model cannot usefully test.
+var generatedMarkers = []string{
+	`extern "C"`,
+	"include!(",
+}
+
+// generatedThreshold is the fraction of non-blank lines that must match a
+// generatedMarkers entry for a file to be considered generated.
+const generatedThreshold = 0.3
+
+// ExcludeGenerated drops files under repoRoot whose content looks
+// machine-generated (bindgen FFI bindings, include!'d generated code),
+// since a model cannot write meaningful tests against them. Files that
+// cannot be read are kept, since a missing file is not this function's
+// concern.
+func ExcludeGenerated(repoRoot string, files []coverage.FileStats) []coverage.FileStats {
+	var kept []coverage.FileStats
+	for _, f := range files {
+		if isGenerated(joinRepoPath(repoRoot, f.Path)) {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+func joinRepoPath(repoRoot, path string) string {
+	if repoRoot == "" || repoRoot == "." {
+		return path
+	}
+	return strings.TrimSuffix(repoRoot, "/") + "/" + path
+}
+
+func isGenerated(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	var total, matched int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		total++
+
+		for _, tag := range generatedTags {
+			if strings.Contains(line, tag) {
+				return true
+			}
+		}
+		for _, marker := range generatedMarkers {
+			if strings.Contains(line, marker) {
+				matched++
+				break
+			}
+		}
+	}
+
+	if total == 0 {
+		return false
+	}
+	return float64(matched)/float64(total) >= generatedThreshold
+}
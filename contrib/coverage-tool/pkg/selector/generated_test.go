@@ -0,0 +1,48 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+func TestExcludeGeneratedDropsBindgenOutput(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "bindings.rs", `// automatically generated by rust-bindgen
+extern "C" {
+    pub fn nydus_open(path: *const i8) -> i32;
+}
+extern "C" {
+    pub fn nydus_close(fd: i32) -> i32;
+}
+`)
+	writeFile(t, dir, "device.rs", `pub struct Device;
+
+impl Device {
+    pub fn open(&self) -> bool {
+        true
+    }
+}
+`)
+
+	files := []coverage.FileStats{{Path: "bindings.rs"}, {Path: "device.rs"}}
+	got := ExcludeGenerated(dir, files)
+
+	if len(got) != 1 || got[0].Path != "device.rs" {
+		t.Errorf("ExcludeGenerated = %v, want [device.rs]", got)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selector
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+// PickInteractive prints candidates as a numbered table to out, prompts
+// in on a substring filter and a comma-separated selection of indices,
+// and returns the chosen subset. An empty filter matches everything.
+func PickInteractive(candidates []coverage.FileStats, in io.Reader, out io.Writer) ([]coverage.FileStats, error) {
+	reader := bufio.NewReader(in)
+
+	fmt.Fprint(out, "filter (substring, empty for none)> ")
+	filterLine, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	filter := strings.TrimSpace(filterLine)
+
+	var filtered []coverage.FileStats
+	for _, c := range candidates {
+		if filter == "" || strings.Contains(c.Path, filter) {
+			filtered = append(filtered, c)
+		}
+	}
+
+	for i, c := range filtered {
+		fmt.Fprintf(out, "%3d) %-60s %6.2f%%\n", i+1, c.Path, c.Percent())
+	}
+
+	fmt.Fprint(out, "select (comma-separated numbers, empty for none)> ")
+	selectionLine, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	selectionLine = strings.TrimSpace(selectionLine)
+	if selectionLine == "" {
+		return nil, nil
+	}
+
+	var selected []coverage.FileStats
+	for _, field := range strings.Split(selectionLine, ",") {
+		idx, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || idx < 1 || idx > len(filtered) {
+			return nil, fmt.Errorf("invalid selection %q", field)
+		}
+		selected = append(selected, filtered[idx-1])
+	}
+
+	return selected, nil
+}
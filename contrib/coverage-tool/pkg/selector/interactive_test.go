@@ -0,0 +1,42 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selector
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+)
+
+func TestPickInteractiveFiltersAndSelects(t *testing.T) {
+	candidates := []coverage.FileStats{
+		{Path: "storage/src/device.rs", LinesCovered: 10, LinesTotal: 100},
+		{Path: "rafs/src/fs.rs", LinesCovered: 90, LinesTotal: 100},
+	}
+
+	in := strings.NewReader("storage\n1\n")
+	var out bytes.Buffer
+
+	got, err := PickInteractive(candidates, in, &out)
+	if err != nil {
+		t.Fatalf("PickInteractive returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].Path != "storage/src/device.rs" {
+		t.Errorf("PickInteractive = %v, want [storage/src/device.rs]", got)
+	}
+}
+
+func TestPickInteractiveEmptySelection(t *testing.T) {
+	candidates := []coverage.FileStats{{Path: "a.rs"}}
+	got, err := PickInteractive(candidates, strings.NewReader("\n\n"), &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("PickInteractive returned error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("PickInteractive with empty selection = %v, want nil", got)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package selector picks which files coverage-tool should generate tests
+// for, out of a full coverage.Report.
+package selector
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+// Select returns up to n files from files, ordered by ascending coverage
+// percentage, so the least-covered files are generated for first.
+func Select(files []coverage.FileStats, n int) []coverage.FileStats {
+	sorted := make([]coverage.FileStats, len(files))
+	copy(sorted, files)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Percent() < sorted[j].Percent()
+	})
+
+	return truncate(sorted, n)
+}
+
+// SelectRoundRobin returns up to n files, preferring files that have never
+// had a successful generation recorded in hist, then files whose last
+// success is oldest, so successive scheduled runs spread improvements
+// across the codebase instead of repeatedly re-picking the same files.
+func SelectRoundRobin(files []coverage.FileStats, n int, hist *history.Store) []coverage.FileStats {
+	sorted := make([]coverage.FileStats, len(files))
+	copy(sorted, files)
+
+	lastSuccess := make(map[string]time.Time, len(sorted))
+	visited := make(map[string]bool, len(sorted))
+	for _, f := range sorted {
+		if t, ok := hist.LastSuccess(f.Path); ok {
+			lastSuccess[f.Path] = t
+			visited[f.Path] = true
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if visited[a.Path] != visited[b.Path] {
+			return !visited[a.Path]
+		}
+		if visited[a.Path] {
+			return lastSuccess[a.Path].Before(lastSuccess[b.Path])
+		}
+		return a.Percent() < b.Percent()
+	})
+
+	return truncate(sorted, n)
+}
+
+// SelectChurnWeighted returns up to n files, ranked by descending risk
+// score: how uncovered a file is, weighted by how often it has recently
+// changed. Frequently changed but poorly covered files are the riskiest
+// to leave untested and sort first.
+func SelectChurnWeighted(files []coverage.FileStats, n int, commitCounts map[string]int) []coverage.FileStats {
+	sorted := make([]coverage.FileStats, len(files))
+	copy(sorted, files)
+
+	score := func(f coverage.FileStats) float64 {
+		return (100 - f.Percent()) * float64(1+commitCounts[f.Path])
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return score(sorted[i]) > score(sorted[j])
+	})
+
+	return truncate(sorted, n)
+}
+
+// SelectZeroFirst returns up to n files, sorting files with 0% coverage
+// ahead of every partially covered file, so bootstrapping a new crate
+// exhausts its untested files before polishing ones that already have
+// some coverage.
+func SelectZeroFirst(files []coverage.FileStats, n int) []coverage.FileStats {
+	sorted := make([]coverage.FileStats, len(files))
+	copy(sorted, files)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		aZero, bZero := a.Percent() == 0, b.Percent() == 0
+		if aZero != bZero {
+			return aZero
+		}
+		return a.Percent() < b.Percent()
+	})
+
+	return truncate(sorted, n)
+}
+
+// SelectRegressionFirst returns up to n files, ranked by how much their
+// coverage has dropped since baseline. Files not present in baseline (new
+// files) or that did not regress sort last, ordered by ascending coverage
+// as a tiebreaker, so regressions introduced by recent changes are fixed
+// before general cleanup.
+func SelectRegressionFirst(files []coverage.FileStats, n int, baseline map[string]coverage.FileStats) []coverage.FileStats {
+	sorted := make([]coverage.FileStats, len(files))
+	copy(sorted, files)
+
+	regression := func(f coverage.FileStats) float64 {
+		before, ok := baseline[f.Path]
+		if !ok {
+			return 0
+		}
+		drop := before.Percent() - f.Percent()
+		if drop < 0 {
+			return 0
+		}
+		return drop
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if ra, rb := regression(a), regression(b); ra != rb {
+			return ra > rb
+		}
+		return a.Percent() < b.Percent()
+	})
+
+	return truncate(sorted, n)
+}
+
+// Options carries the extra, strategy-specific inputs Apply needs beyond
+// the coverage report itself.
+type Options struct {
+	// History is consulted by history-aware strategies (round-robin).
+	History *history.Store
+	// CommitCounts is consulted by the churn strategy: recent commit
+	// count per file path.
+	CommitCounts map[string]int
+	// Baseline is consulted by the regression strategy: coverage stats
+	// per file path from a prior run.
+	Baseline map[string]coverage.FileStats
+}
+
+// Apply dispatches to the named selection strategy: "coverage" (the
+// default), "round-robin", "churn", "zero-first", or "regression".
+func Apply(strategy string, files []coverage.FileStats, n int, opts Options) ([]coverage.FileStats, error) {
+	switch strategy {
+	case "", "coverage":
+		return Select(files, n), nil
+	case "round-robin":
+		return SelectRoundRobin(files, n, opts.History), nil
+	case "churn":
+		return SelectChurnWeighted(files, n, opts.CommitCounts), nil
+	case "zero-first":
+		return SelectZeroFirst(files, n), nil
+	case "regression":
+		return SelectRegressionFirst(files, n, opts.Baseline), nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy %q", strategy)
+	}
+}
+
+func truncate(files []coverage.FileStats, n int) []coverage.FileStats {
+	if n <= 0 || n > len(files) {
+		n = len(files)
+	}
+	return files[:n]
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/coverage"
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/history"
+)
+
+func TestSelectOrdersByAscendingCoverage(t *testing.T) {
+	files := []coverage.FileStats{
+		{Path: "a.rs", LinesCovered: 80, LinesTotal: 100},
+		{Path: "b.rs", LinesCovered: 20, LinesTotal: 100},
+	}
+
+	got := Select(files, 1)
+	if len(got) != 1 || got[0].Path != "b.rs" {
+		t.Errorf("Select = %v, want [b.rs]", got)
+	}
+}
+
+func TestSelectRoundRobinPrefersUnvisited(t *testing.T) {
+	hist, err := history.Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := hist.Record("a.rs", true, "", 0, 0, 0); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+
+	files := []coverage.FileStats{
+		{Path: "a.rs", LinesCovered: 20, LinesTotal: 100},
+		{Path: "b.rs", LinesCovered: 80, LinesTotal: 100},
+	}
+
+	got := SelectRoundRobin(files, 1, hist)
+	if len(got) != 1 || got[0].Path != "b.rs" {
+		t.Errorf("SelectRoundRobin = %v, want [b.rs] (never visited, despite lower coverage in a.rs)", got)
+	}
+}
+
+func TestApplyUnknownStrategy(t *testing.T) {
+	hist, _ := history.Open(filepath.Join(t.TempDir(), "history.json"))
+	if _, err := Apply("bogus", nil, 0, Options{History: hist}); err == nil {
+		t.Error("Apply with an unknown strategy should return an error")
+	}
+}
+
+func TestSelectZeroFirstPrefersZeroCoverageEvenIfLowerCoverageExists(t *testing.T) {
+	files := []coverage.FileStats{
+		{Path: "barely.rs", LinesCovered: 1, LinesTotal: 100},
+		{Path: "untested.rs", LinesCovered: 0, LinesTotal: 100},
+	}
+
+	got := SelectZeroFirst(files, 1)
+	if len(got) != 1 || got[0].Path != "untested.rs" {
+		t.Errorf("SelectZeroFirst = %v, want [untested.rs] (0%% coverage exhausted before partial coverage)", got)
+	}
+}
+
+func TestSelectRegressionFirstPrefersLargestDrop(t *testing.T) {
+	files := []coverage.FileStats{
+		{Path: "steady.rs", LinesCovered: 50, LinesTotal: 100},
+		{Path: "regressed.rs", LinesCovered: 30, LinesTotal: 100},
+	}
+	baseline := map[string]coverage.FileStats{
+		"steady.rs":    {Path: "steady.rs", LinesCovered: 50, LinesTotal: 100},
+		"regressed.rs": {Path: "regressed.rs", LinesCovered: 90, LinesTotal: 100},
+	}
+
+	got := SelectRegressionFirst(files, 1, baseline)
+	if len(got) != 1 || got[0].Path != "regressed.rs" {
+		t.Errorf("SelectRegressionFirst = %v, want [regressed.rs] (dropped 60%% since baseline)", got)
+	}
+}
+
+func TestSelectChurnWeightedPrefersHighChurnLowCoverage(t *testing.T) {
+	files := []coverage.FileStats{
+		{Path: "hot.rs", LinesCovered: 90, LinesTotal: 100},
+		{Path: "cold.rs", LinesCovered: 10, LinesTotal: 100},
+	}
+	commitCounts := map[string]int{"hot.rs": 50, "cold.rs": 1}
+
+	got := SelectChurnWeighted(files, 1, commitCounts)
+	if len(got) != 1 || got[0].Path != "hot.rs" {
+		t.Errorf("SelectChurnWeighted = %v, want [hot.rs] (high churn outweighs its better coverage)", got)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package term renders coverage-tool's terminal output as aligned tables
+// with optional ANSI colors, so large analyze/diff outputs stay readable
+// without depending on an external table or color library.
+package term
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dragonflyoss/nydus/contrib/coverage-tool/pkg/badge"
+)
+
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// ColorEnabled reports whether ANSI colors should be used: off if noColor
+// is set (a command's --no-color flag) or the NO_COLOR environment
+// variable is set to any non-empty value, the convention at
+// https://no-color.org that CI systems and terminal multiplexers already
+// honor.
+func ColorEnabled(noColor bool) bool {
+	return !noColor && os.Getenv("NO_COLOR") == ""
+}
+
+func colorize(enabled bool, code, s string) string {
+	if !enabled {
+		return s
+	}
+	return code + s + colorReset
+}
+
+// Red colors s red if enabled, for regressions.
+func Red(enabled bool, s string) string {
+	return colorize(enabled, colorRed, s)
+}
+
+// Green colors s green if enabled, for improvements.
+func Green(enabled bool, s string) string {
+	return colorize(enabled, colorGreen, s)
+}
+
+// Delta colors s green if delta is non-negative and red otherwise, if
+// enabled.
+func Delta(enabled bool, delta float64, s string) string {
+	if delta < 0 {
+		return Red(enabled, s)
+	}
+	return Green(enabled, s)
+}
+
+// Coverage colors s by percent using the same red/yellow/green bands as
+// badge.ColorFor, so a percentage means the same thing whether it's read
+// off a badge or a terminal table.
+func Coverage(enabled bool, percent float64, s string) string {
+	switch badge.ColorFor(percent) {
+	case "brightgreen", "green":
+		return Green(enabled, s)
+	case "yellowgreen", "yellow", "orange":
+		return colorize(enabled, colorYellow, s)
+	default:
+		return Red(enabled, s)
+	}
+}
+
+// Table renders left-aligned columns to a writer, sizing each column from
+// its widest cell.
+type Table struct {
+	headers []string
+	rows    [][]string
+}
+
+// NewTable creates a Table with the given column headers.
+func NewTable(headers ...string) *Table {
+	return &Table{headers: headers}
+}
+
+// AddRow appends a row. len(cells) should match the header count.
+func (t *Table) AddRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// Render writes the table to w: a header row, a dashed separator, then
+// every data row, each column padded to its widest cell.
+func (t *Table) Render(w io.Writer) {
+	widths := make([]int, len(t.headers))
+	for i, h := range t.headers {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				if cw := displayWidth(cell); cw > widths[i] {
+					widths[i] = cw
+				}
+			}
+		}
+	}
+
+	printRow(w, t.headers, widths)
+	printRow(w, dashes(widths), widths)
+	for _, row := range t.rows {
+		printRow(w, row, widths)
+	}
+}
+
+func dashes(widths []int) []string {
+	out := make([]string, len(widths))
+	for i, w := range widths {
+		out[i] = strings.Repeat("-", w)
+	}
+	return out
+}
+
+func printRow(w io.Writer, cells []string, widths []int) {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		pad := 0
+		if i < len(widths) {
+			pad = widths[i] - displayWidth(cell)
+		}
+		if pad < 0 {
+			pad = 0
+		}
+		padded[i] = cell + strings.Repeat(" ", pad)
+	}
+	fmt.Fprintln(w, strings.Join(padded, "  "))
+}
+
+// displayWidth returns cell's rendered width, skipping ANSI escape
+// sequences, so colorized cells still align.
+func displayWidth(cell string) int {
+	width := 0
+	inEscape := false
+	for _, r := range cell {
+		switch {
+		case inEscape:
+			if r == 'm' {
+				inEscape = false
+			}
+		case r == '\x1b':
+			inEscape = true
+		default:
+			width++
+		}
+	}
+	return width
+}
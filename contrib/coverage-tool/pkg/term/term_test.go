@@ -0,0 +1,67 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package term
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColorEnabledHonorsNoColorFlagAndEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if !ColorEnabled(false) {
+		t.Error("ColorEnabled(false) = false, want true when NO_COLOR is unset")
+	}
+	if ColorEnabled(true) {
+		t.Error("ColorEnabled(true) = true, want false when --no-color is passed")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled(false) {
+		t.Error("ColorEnabled(false) = true, want false when NO_COLOR is set")
+	}
+}
+
+func TestDeltaColorsRegressionsRedAndImprovementsGreen(t *testing.T) {
+	if got := Delta(true, -1, "x"); !strings.Contains(got, colorRed) {
+		t.Errorf("Delta(regression) = %q, want it to contain the red escape code", got)
+	}
+	if got := Delta(true, 1, "x"); !strings.Contains(got, colorGreen) {
+		t.Errorf("Delta(improvement) = %q, want it to contain the green escape code", got)
+	}
+	if got := Delta(false, -1, "x"); got != "x" {
+		t.Errorf("Delta(disabled) = %q, want plain %q", got, "x")
+	}
+}
+
+func TestCoverageColorsByBadgeBand(t *testing.T) {
+	if got := Coverage(true, 95, "x"); !strings.Contains(got, colorGreen) {
+		t.Errorf("Coverage(95) = %q, want it to contain the green escape code", got)
+	}
+	if got := Coverage(true, 50, "x"); !strings.Contains(got, colorYellow) {
+		t.Errorf("Coverage(50) = %q, want it to contain the yellow escape code", got)
+	}
+	if got := Coverage(true, 10, "x"); !strings.Contains(got, colorRed) {
+		t.Errorf("Coverage(10) = %q, want it to contain the red escape code", got)
+	}
+}
+
+func TestTableRenderAlignsColorizedCells(t *testing.T) {
+	table := NewTable("File", "Delta")
+	table.AddRow("a.rs", Red(true, "-5.00%"))
+	table.AddRow("bb.rs", Green(true, "+10.00%"))
+
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Render() produced %d lines, want 4 (header, separator, 2 rows)", len(lines))
+	}
+	if !strings.HasPrefix(lines[2], "a.rs ") {
+		t.Errorf("Render() row 1 = %q, want it left-padded to align with %q", lines[2], "bb.rs")
+	}
+}
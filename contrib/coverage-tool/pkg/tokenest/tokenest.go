@@ -0,0 +1,26 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tokenest estimates how many tokens a prompt will consume, so
+// candidates that cannot fit a model's context window can be filtered out
+// before generation is attempted, rather than failing inside the API call.
+package tokenest
+
+// charsPerToken approximates the number of characters per token for
+// English and source code text. It is a coarse heuristic, not a real
+// tokenizer, but is accurate enough to guard against blowing a context
+// window without pulling in a per-model tokenizer dependency.
+const charsPerToken = 4
+
+// Estimate returns the approximate number of tokens text will consume.
+func Estimate(text string) int {
+	return len(text)/charsPerToken + 1
+}
+
+// Fits reports whether a prompt of estimated size promptTokens leaves
+// room in a contextWindow-token model after reserving reserveTokens for
+// the completion.
+func Fits(promptTokens, contextWindow, reserveTokens int) bool {
+	return promptTokens+reserveTokens <= contextWindow
+}
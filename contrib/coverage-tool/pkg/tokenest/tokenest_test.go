@@ -0,0 +1,24 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenest
+
+import "testing"
+
+func TestFits(t *testing.T) {
+	if !Fits(1000, 4096, 1024) {
+		t.Error("Fits should be true when prompt and reserve fit within the window")
+	}
+	if Fits(3200, 4096, 1024) {
+		t.Error("Fits should be false when prompt and reserve exceed the window")
+	}
+}
+
+func TestEstimateScalesWithLength(t *testing.T) {
+	short := Estimate("hello")
+	long := Estimate("hello, this is a much longer piece of text than the other one")
+	if long <= short {
+		t.Errorf("Estimate(long) = %d, want more than Estimate(short) = %d", long, short)
+	}
+}
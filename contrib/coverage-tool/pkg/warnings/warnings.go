@@ -0,0 +1,91 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package warnings persists the non-fatal warnings a coverage-tool run
+// emits (skipped files, fallbacks, redactions, truncations) to a
+// structured, file-backed log, so they survive as data available to the
+// report stage instead of disappearing into scattered log lines that CI
+// systems don't retain.
+package warnings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Warning is a single non-fatal event recorded during a run.
+type Warning struct {
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Store is an append-only, file-backed log of warnings, following the
+// same on-disk convention as pkg/history's generation attempts.
+type Store struct {
+	path     string
+	Warnings []Warning `json:"warnings"`
+}
+
+// Open loads the warnings store at path, creating an empty one if it
+// does not yet exist.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read warnings store")
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrap(err, "parse warnings store")
+	}
+	s.path = path
+
+	return s, nil
+}
+
+// Warnf logs message at warning level, exactly as logrus.Warnf would, and
+// also appends it to the store and persists it to disk.
+func (s *Store) Warnf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	logrus.Warn(message)
+
+	s.Warnings = append(s.Warnings, Warning{Message: message, Timestamp: time.Now()})
+	if err := s.save(); err != nil {
+		logrus.Warnf("failed to persist warnings store: %v", err)
+	}
+}
+
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return errors.Wrap(err, "create warnings directory")
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal warnings store")
+	}
+
+	return errors.Wrap(os.WriteFile(s.path, data, 0o644), "write warnings store")
+}
+
+// Messages returns every recorded warning message, oldest first.
+func (s *Store) Messages() []string {
+	messages := make([]string, len(s.Warnings))
+	for i, w := range s.Warnings {
+		messages[i] = w.Message
+	}
+	return messages
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package warnings
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWarnfPersistsAcrossOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "warnings.json")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	s.Warnf("skipping %s: too large", "storage/src/device.rs")
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("re-Open returned error: %v", err)
+	}
+
+	messages := reopened.Messages()
+	if len(messages) != 1 || messages[0] != "skipping storage/src/device.rs: too large" {
+		t.Errorf("Messages() = %v, want a single persisted warning", messages)
+	}
+}
+
+func TestMessagesEmptyForNewStore(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "warnings.json"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	if messages := s.Messages(); len(messages) != 0 {
+		t.Errorf("Messages() = %v, want none for a new store", messages)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook posts JSON events at coverage-tool's stage boundaries
+// (analysis done, generation done, validation pass/fail, report ready),
+// optionally HMAC-signed, so internal bots and dashboards can react to
+// runs without polling any of coverage-tool's file-backed stores.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Event is a single stage-boundary notification.
+type Event struct {
+	Stage     string         `json:"stage"`
+	Timestamp time.Time      `json:"timestamp"`
+	File      string         `json:"file"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// signatureHeader carries the event body's HMAC-SHA256 signature, hex
+// encoded, when secret is non-empty; a receiver verifies it the same way
+// GitHub webhooks do, to reject forged events.
+const signatureHeader = "X-Coverage-Tool-Signature"
+
+// Send POSTs event as JSON to url, signing the body with secret (if
+// non-empty) and setting the signature in the X-Coverage-Tool-Signature
+// header as "sha256=<hex>".
+func Send(url, secret string, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "marshal webhook event")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "build webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(signatureHeader, "sha256="+sign(body, secret))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("webhook %s returned status %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of body keyed by secret.
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
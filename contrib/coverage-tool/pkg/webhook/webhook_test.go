@@ -0,0 +1,76 @@
+// Copyright 2026 Ant Group. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendSignsBodyWhenSecretSet(t *testing.T) {
+	const secret = "s3cr3t"
+	var gotBody []byte
+	var gotSignature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(signatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	event := Event{Stage: "analysis-done", File: "storage/src/device.rs"}
+	if err := Send(server.URL, secret, event); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(gotBody)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if gotSignature != want {
+		t.Errorf("signature = %q, want %q", gotSignature, want)
+	}
+
+	var decoded Event
+	if err := json.Unmarshal(gotBody, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded.Stage != event.Stage || decoded.File != event.File {
+		t.Errorf("decoded event = %+v, want stage/file matching %+v", decoded, event)
+	}
+}
+
+func TestSendOmitsSignatureWhenSecretEmpty(t *testing.T) {
+	var gotSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(signatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := Send(server.URL, "", Event{Stage: "report-ready"}); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if gotSignature != "" {
+		t.Errorf("signature = %q, want empty when no secret is set", gotSignature)
+	}
+}
+
+func TestSendReturnsErrorOnNonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := Send(server.URL, "", Event{Stage: "generation-done"}); err == nil {
+		t.Error("Send should return an error for a 500 response")
+	}
+}